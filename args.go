@@ -10,16 +10,15 @@ func unifyArgs(args ...interface{}) ([]interface{}, int) {
 	count := 0
 	var newArgs []interface{}
 	for _, arg := range args {
-		s := reflect.ValueOf(arg)
-		switch reflect.TypeOf(arg).Kind() {
-		case reflect.Slice:
+		if isListArg(arg) {
+			s := reflect.ValueOf(arg)
 			count += s.Len()
 			for i := 0; i < s.Len(); i++ {
 				newArgs = append(newArgs, s.Index(i).Interface())
 			}
-		default:
+		} else {
 			count++
-			newArgs = append(newArgs, s.Interface())
+			newArgs = append(newArgs, arg)
 		}
 	}
 	return newArgs, count
@@ -48,35 +48,42 @@ func BindType(driverName DriverName) int {
 	return UNKNOWN
 }
 
-// rebind a query table the default bindtype (QUESTION) to the target bindtype.
-func rebind(bindType int, query string) string {
-	switch bindType {
-	case QUESTION, UNKNOWN:
+// rebind a query table the default bindtype (QUESTION) to the bindtype of the dialect d.
+// Escaped question marks (??) are replaced by a literal question mark.
+func rebind(d Dialect, query string) string {
+	if !strings.Contains(query, "?") {
 		return query
 	}
 
 	// Add space enough for 10 params before we have to allocate
 	rqb := make([]byte, 0, len(query)+10)
 
-	var i, j int
+	var j int
 
-	for i = strings.Index(query, "?"); i != -1; i = strings.Index(query, "?") {
-		rqb = append(rqb, query[:i]...)
+	bindType := d.BindType()
+	scanQuery(d, query, func(kind tokenKind, text string) {
+		switch kind {
+		case tokenPlaceholder:
+			switch bindType {
+			case DOLLAR:
+				rqb = append(rqb, '$')
+			case NAMED:
+				rqb = append(rqb, ':', 'a', 'r', 'g')
+			case AT:
+				rqb = append(rqb, '@', 'p')
+			default:
+				rqb = append(rqb, '?')
+				return
+			}
 
-		switch bindType {
-		case DOLLAR:
-			rqb = append(rqb, '$')
-		case NAMED:
-			rqb = append(rqb, ':', 'a', 'r', 'g')
-		case AT:
-			rqb = append(rqb, '@', 'p')
+			j++
+			rqb = strconv.AppendInt(rqb, int64(j), 10)
+		case tokenQuestionMark:
+			rqb = append(rqb, '?')
+		default:
+			rqb = append(rqb, text...)
 		}
+	})
 
-		j++
-		rqb = strconv.AppendInt(rqb, int64(j), 10)
-
-		query = query[i+1:]
-	}
-
-	return string(append(rqb, query...))
+	return string(rqb)
 }
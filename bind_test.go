@@ -4,8 +4,8 @@ import "testing"
 
 func Test_rebind(t *testing.T) {
 	type args struct {
-		bindType int
-		query    string
+		dialect Dialect
+		query   string
 	}
 	tests := []struct {
 		name string
@@ -15,71 +15,111 @@ func Test_rebind(t *testing.T) {
 		{
 			name: "test1",
 			args: args{
-				bindType: DOLLAR,
-				query:    "SELECT * FROM table1 WHERE id=?",
+				dialect: DialectPostgres,
+				query:   "SELECT * FROM table1 WHERE id=?",
 			},
 			want: "SELECT * FROM table1 WHERE id=$1",
 		},
 		{
 			name: "test2",
 			args: args{
-				bindType: DOLLAR,
-				query:    "SELECT * FROM table1 WHERE id=? and name=?",
+				dialect: DialectPostgres,
+				query:   "SELECT * FROM table1 WHERE id=? and name=?",
 			},
 			want: "SELECT * FROM table1 WHERE id=$1 and name=$2",
 		},
 		{
 			name: "test3",
 			args: args{
-				bindType: QUESTION,
-				query:    "SELECT * FROM table1 WHERE id=?",
+				dialect: DialectDefault,
+				query:   "SELECT * FROM table1 WHERE id=?",
 			},
 			want: "SELECT * FROM table1 WHERE id=?",
 		},
 		{
 			name: "test4",
 			args: args{
-				bindType: QUESTION,
-				query:    "SELECT * FROM table1 WHERE id=? and name=?",
+				dialect: DialectDefault,
+				query:   "SELECT * FROM table1 WHERE id=? and name=?",
 			},
 			want: "SELECT * FROM table1 WHERE id=? and name=?",
 		},
 		{
 			name: "test5",
 			args: args{
-				bindType: NAMED,
-				query:    "SELECT * FROM table1 WHERE id=?",
+				dialect: DialectOCI8,
+				query:   "SELECT * FROM table1 WHERE id=?",
 			},
 			want: "SELECT * FROM table1 WHERE id=:arg1",
 		},
 		{
 			name: "test6",
 			args: args{
-				bindType: NAMED,
-				query:    "SELECT * FROM table1 WHERE id=? and name=?",
+				dialect: DialectOCI8,
+				query:   "SELECT * FROM table1 WHERE id=? and name=?",
 			},
 			want: "SELECT * FROM table1 WHERE id=:arg1 and name=:arg2",
 		},
 		{
 			name: "test7",
 			args: args{
-				bindType: AT,
-				query:    "SELECT * FROM table1 WHERE id=?",
+				dialect: DialectSqlServer,
+				query:   "SELECT * FROM table1 WHERE id=?",
 			},
 			want: "SELECT * FROM table1 WHERE id=@p1",
 		},
 		{
 			name: "test8",
 			args: args{
-				bindType: AT,
-				query:    "SELECT * FROM table1 WHERE id=? and name=?",
+				dialect: DialectSqlServer,
+				query:   "SELECT * FROM table1 WHERE id=? and name=?",
 			},
 			want: "SELECT * FROM table1 WHERE id=@p1 and name=@p2",
 		},
+		{
+			name: "test9 - string literal",
+			args: args{
+				dialect: DialectPostgres,
+				query:   "SELECT * FROM table1 WHERE note='what?' AND id=?",
+			},
+			want: "SELECT * FROM table1 WHERE note='what?' AND id=$1",
+		},
+		{
+			name: "test10 - comments",
+			args: args{
+				dialect: DialectSqlServer,
+				query:   "SELECT * FROM table1 -- why?\nWHERE /* really? */ id=?",
+			},
+			want: "SELECT * FROM table1 -- why?\nWHERE /* really? */ id=@p1",
+		},
+		{
+			name: "test11 - escaped question marks",
+			args: args{
+				dialect: DialectPostgres,
+				query:   "SELECT * FROM table1 WHERE data ?? ? AND data ??| ? AND data ??& ?",
+			},
+			want: "SELECT * FROM table1 WHERE data ? $1 AND data ?| $2 AND data ?& $3",
+		},
+		{
+			name: "test12 - escaped question marks with QUESTION",
+			args: args{
+				dialect: DialectDefault,
+				query:   "SELECT * FROM table1 WHERE data ?? ? AND note='??'",
+			},
+			want: "SELECT * FROM table1 WHERE data ? ? AND note='??'",
+		},
+		{
+			name: "test13 - backslash escape and hash comment",
+			args: args{
+				dialect: DialectMySQL,
+				query:   `SELECT * FROM table1 WHERE note='it\'s?' AND id=? # why?`,
+			},
+			want: `SELECT * FROM table1 WHERE note='it\'s?' AND id=? # why?`,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := rebind(tt.args.bindType, tt.args.query); got != tt.want {
+			if got := rebind(tt.args.dialect, tt.args.query); got != tt.want {
 				t.Errorf("rebind() = %v, want %v", got, tt.want)
 			}
 		})
@@ -1,25 +1,38 @@
 package querybuilder
 
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
 type columnClause struct {
-	query string
-	args  []interface{}
+	column Sqlizer
 }
 
 type whereClause struct {
-	query string
-	args  []interface{}
+	condition Sqlizer
+	or        bool
 }
 
-type havingClause struct {
-	query string
-	args  []interface{}
+type joinClause struct {
+	table    Sqlizer
+	on       Sqlizer
+	joinType JoinType
 }
 
-type joinClause struct {
-	tableName string
-	on        string
-	joinType  JoinType
-	args      []interface{}
+type withClause struct {
+	name      string
+	columns   string
+	query     Sqlizer
+	recursive bool
+}
+
+type compoundClause struct {
+	operator string
+	query    *SelectQuery
 }
 
 type groupByClause struct {
@@ -30,3 +43,201 @@ type orderByClause struct {
 	field     string
 	direction OrderDirection
 }
+
+// buildReturning renders the columns returned by a query, either as a RETURNING clause to append to the query
+// or, for dialects with FeatureOutput, as an OUTPUT clause of the columns of pseudoTable (INSERTED or DELETED) which the caller places.
+// Both are empty when no column is returned.
+func buildReturning(d Dialect, columns []string, pseudoTable string) (returning string, output string, err error) {
+	if len(columns) == 0 {
+		return "", "", nil
+	}
+	switch {
+	case d.Supports(FeatureReturning):
+		return " RETURNING " + strings.Join(columns, ","), "", nil
+	case d.Supports(FeatureOutput):
+		outputColumns := make([]string, len(columns))
+		for i, column := range columns {
+			outputColumns[i] = pseudoTable + "." + column
+		}
+		return "", " OUTPUT " + strings.Join(outputColumns, ","), nil
+	}
+	return "", "", errors.New(ErrReturningNotSupported)
+}
+
+// buildJoins renders each join as JOIN table ON condition preceded by a space
+func buildJoins(d Dialect, joins []joinClause) (string, []interface{}, error) {
+	var sb strings.Builder
+	var args []interface{}
+	for _, join := range joins {
+		table, tableArgs, err := renderSql(d, join.table)
+		if err != nil {
+			return "", nil, err
+		}
+		on, onArgs, err := renderSql(d, join.on)
+		if err != nil {
+			return "", nil, err
+		}
+		args = append(args, tableArgs...)
+		args = append(args, onArgs...)
+		sb.WriteString(" " + joinTypeString(join.joinType) + " " + table + " ON " + on)
+	}
+	return sb.String(), args, nil
+}
+
+// buildJoinTables renders the tables of inner joins as the comma separated list of UPDATE ... FROM and DELETE ... USING.
+// The ON conditions of the joins are returned as where clauses followed by conditions.
+func buildJoinTables(d Dialect, joins []joinClause, conditions []whereClause) (string, []interface{}, []whereClause, error) {
+	tables := make([]string, len(joins))
+	var args []interface{}
+	var joinConditions []whereClause
+	for i, join := range joins {
+		if join.joinType != JoinInner {
+			return "", nil, nil, errors.New(ErrJoinTypeNotSupported)
+		}
+		table, tableArgs, err := renderSql(d, join.table)
+		if err != nil {
+			return "", nil, nil, err
+		}
+		tables[i] = table
+		args = append(args, tableArgs...)
+		joinConditions = append(joinConditions, whereClause{condition: join.on})
+	}
+	return strings.Join(tables, ","), args, append(joinConditions, groupConditions(conditions)...), nil
+}
+
+// groupConditions returns the conditions as at most one where clause, so they can be joined with AND to other conditions
+func groupConditions(conditions []whereClause) []whereClause {
+	switch len(conditions) {
+	case 0:
+		return nil
+	case 1:
+		return []whereClause{{condition: conditions[0].condition}}
+	default:
+		return []whereClause{{condition: &ConditionGroup{conditions: conditions}}}
+	}
+}
+
+// pkConditions returns the condition on the primary key set by StructValues or WherePK, with quoted columns,
+// followed by conditions grouped so that an OR among them cannot widen the query past the primary key
+func pkConditions(q identifierQuoter, pk Eq, conditions []whereClause) ([]whereClause, error) {
+	if len(pk) == 0 {
+		return conditions, nil
+	}
+	columns := make([]string, 0, len(pk))
+	for column := range pk {
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+
+	parts := make([]string, len(columns))
+	args := make([]interface{}, len(columns))
+	for i, column := range columns {
+		if pk[column] == nil {
+			return nil, fmt.Errorf("%s: %s", ErrPrimaryKeyIsNull, column)
+		}
+		quoted, err := q.column(column)
+		if err != nil {
+			return nil, err
+		}
+		parts[i] = quoted + "=?"
+		args[i] = pk[column]
+	}
+	condition := whereClause{condition: expr{sql: strings.Join(parts, " AND "), args: args}}
+	return append([]whereClause{condition}, groupConditions(conditions)...), nil
+}
+
+// tableAlias returns the alias of a table expression like "users u", or the table itself
+func tableAlias(table string) string {
+	fields := strings.Fields(table)
+	if len(fields) == 0 {
+		return table
+	}
+	return fields[len(fields)-1]
+}
+
+// buildOrderBy renders the columns of an ORDER BY with their direction
+func buildOrderBy(orderBy []orderByClause) string {
+	orderBySlice := make([]string, len(orderBy))
+	for i, clause := range orderBy {
+		orderBySlice[i] = clause.field + " " + orderDirectionString(clause.direction)
+	}
+	return strings.Join(orderBySlice, ",")
+}
+
+// toInt64 converts the value of Limit or Offset, an integer or a numeric string, to int64. Other values are 0.
+func toInt64(value interface{}) int64 {
+	switch v := value.(type) {
+	case int8:
+		return int64(v)
+	case int16:
+		return int64(v)
+	case int32:
+		return int64(v)
+	case int64:
+		return v
+	case int:
+		return int64(v)
+	case string:
+		i, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return 0
+		}
+		return i
+	default:
+		return 0
+	}
+}
+
+// buildConditions wraps each condition in parentheses and joins them with AND or OR, empty conditions are skipped.
+// Groups are already parenthesized.
+func buildConditions(d Dialect, conditions []whereClause) (string, []interface{}, error) {
+	var sb strings.Builder
+	var args []interface{}
+	for _, condition := range conditions {
+		query, conditionArgs, err := renderSql(d, condition.condition)
+		if err != nil {
+			return "", nil, err
+		}
+		if query == "" {
+			continue
+		}
+		if sb.Len() > 0 {
+			if condition.or {
+				sb.WriteString(" OR ")
+			} else {
+				sb.WriteString(" AND ")
+			}
+		}
+		if _, ok := condition.condition.(*ConditionGroup); !ok {
+			query = "(" + query + ")"
+		}
+		sb.WriteString(query)
+		args = append(args, conditionArgs...)
+	}
+	return sb.String(), args, nil
+}
+
+// buildWith renders the WITH part of a query followed by a space
+func buildWith(d Dialect, ctes []withClause) (string, []interface{}, error) {
+	var ctesSlice []string
+	var args []interface{}
+	recursive := false
+	for _, cte := range ctes {
+		query, cteArgs, err := renderSql(d, cte.query)
+		if err != nil {
+			return "", nil, err
+		}
+		name := cte.name
+		if cte.columns != "" {
+			name = name + "(" + cte.columns + ")"
+		}
+		ctesSlice = append(ctesSlice, name+" AS ("+query+")")
+		args = append(args, cteArgs...)
+		recursive = recursive || cte.recursive
+	}
+	with := "WITH "
+	if recursive && d.Supports(FeatureWithRecursive) {
+		with = "WITH RECURSIVE "
+	}
+	return with + strings.Join(ctesSlice, ",") + " ", args, nil
+}
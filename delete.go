@@ -1,21 +1,33 @@
 package querybuilder
 
 import (
+	"context"
+	"database/sql"
 	"errors"
-	"fmt"
-	"strings"
+	"log"
+	"strconv"
 )
 
 type DeleteQuery struct {
+	dialect    Dialect
+	ctes       []withClause
 	table      string
+	joins      []joinClause
 	conditions []whereClause
+	returning  []string
+	quoteMode  QuoteMode
+	runner     Runner
+	orderBy    []orderByClause
+	limit      interface{}
+	// pk is the primary key set by WherePK, rendered as a condition
+	pk Eq
 }
 
-func (s *DeleteQuery) Where(query string, args ...interface{}) *DeleteQuery {
+// Where adds a condition, query is a raw query string with its args or a Sqlizer like Eq or Gt
+func (s *DeleteQuery) Where(query interface{}, args ...interface{}) *DeleteQuery {
 	args, _ = unifyArgs(args...)
 	condition := whereClause{
-		query: query,
-		args:  args,
+		condition: toSqlizer(query, args),
 	}
 	newQuery := *s
 
@@ -23,29 +35,259 @@ func (s *DeleteQuery) Where(query string, args ...interface{}) *DeleteQuery {
 	return &newQuery
 }
 
+// WherePK adds a condition on the fields of a struct tagged with pk, it replaces the one of a previous call.
+// The other conditions are grouped, so an OrWhere does not widen the query past the primary key.
+func (s *DeleteQuery) WherePK(structure interface{}) *DeleteQuery {
+	_, pk, err := structToMap(structure, structUpdate)
+	if err != nil {
+		log.Panic(err)
+	}
+	if len(pk) == 0 {
+		log.Panic(errors.New(ErrPrimaryKeyIsEmpty))
+	}
+	newQuery := *s
+	newQuery.pk = pk
+	return &newQuery
+}
+
+// OrWhere adds a condition which is joined to the previous conditions with OR
+func (s *DeleteQuery) OrWhere(query interface{}, args ...interface{}) *DeleteQuery {
+	args, _ = unifyArgs(args...)
+	condition := whereClause{
+		condition: toSqlizer(query, args),
+		or:        true,
+	}
+	newQuery := *s
+
+	newQuery.conditions = append(newQuery.conditions, condition)
+	return &newQuery
+}
+
+// WhereGroup adds a parenthesized group of conditions joined with AND, the group is filled by fn
+func (s *DeleteQuery) WhereGroup(fn func(g *ConditionGroup)) *DeleteQuery {
+	condition := whereClause{
+		condition: newConditionGroup(fn),
+	}
+	newQuery := *s
+
+	newQuery.conditions = append(newQuery.conditions, condition)
+	return &newQuery
+}
+
+// OrWhereGroup adds a parenthesized group of conditions joined with OR, the group is filled by fn
+func (s *DeleteQuery) OrWhereGroup(fn func(g *ConditionGroup)) *DeleteQuery {
+	condition := whereClause{
+		condition: newConditionGroup(fn),
+		or:        true,
+	}
+	newQuery := *s
+
+	newQuery.conditions = append(newQuery.conditions, condition)
+	return &newQuery
+}
+
+// Joins adds a join with another table, tableName is a table name or a Sqlizer like a subquery created by As.
+// It is rendered as DELETE ... USING or DELETE t FROM t JOIN depending on the dialect, only inner joins can be moved to the WHERE part.
+func (s *DeleteQuery) Joins(tableName interface{}, on interface{}, joinType JoinType, args ...interface{}) *DeleteQuery {
+	args, _ = unifyArgs(args...)
+	join := joinClause{
+		table:    tableSqlizer(tableName),
+		on:       toSqlizer(on, args),
+		joinType: joinType,
+	}
+	newQuery := *s
+	newQuery.joins = append(newQuery.joins, join)
+	return &newQuery
+}
+
+// Returning sets the columns returned by the query, rendered as RETURNING or, on SQL Server, as OUTPUT DELETED.column
+func (s *DeleteQuery) Returning(columns ...string) *DeleteQuery {
+	newQuery := *s
+	newQuery.returning = columns
+	return &newQuery
+}
+
+// Order adds a column to the ORDER BY of the query, only supported by MySQL and SQLite
+func (s *DeleteQuery) Order(column string, direction OrderDirection) *DeleteQuery {
+	clause := orderByClause{
+		field:     column,
+		direction: direction,
+	}
+	newQuery := *s
+	newQuery.orderBy = append(newQuery.orderBy, clause)
+	return &newQuery
+}
+
+// Limit sets the maximum number of rows changed by the query, only supported by MySQL and SQLite
+func (s *DeleteQuery) Limit(limit interface{}) *DeleteQuery {
+	newQuery := *s
+	newQuery.limit = toInt64(limit)
+	return &newQuery
+}
+
+// With adds a common table expression, query is a raw query string with its args or a Sqlizer like a SelectQuery
+func (s *DeleteQuery) With(name string, query interface{}, args ...interface{}) *DeleteQuery {
+	args, _ = unifyArgs(args...)
+	cte := withClause{
+		name:  name,
+		query: toSqlizer(query, args),
+	}
+	newQuery := *s
+	newQuery.ctes = append(newQuery.ctes, cte)
+	return &newQuery
+}
+
+// WithRecursive adds a recursive common table expression with its columns
+func (s *DeleteQuery) WithRecursive(name string, columns string, query interface{}, args ...interface{}) *DeleteQuery {
+	args, _ = unifyArgs(args...)
+	cte := withClause{
+		name:      name,
+		columns:   columns,
+		query:     toSqlizer(query, args),
+		recursive: true,
+	}
+	newQuery := *s
+	newQuery.ctes = append(newQuery.ctes, cte)
+	return &newQuery
+}
+
+// quoter returns the identifierQuoter of the query's dialect and quote mode
+func (s *DeleteQuery) quoter() identifierQuoter {
+	return identifierQuoter{dialect: s.dialect, mode: s.quoteMode}
+}
+
+// RunWith sets the runner which executes the query, like a *sql.DB, *sql.Tx or *sql.Conn
+func (s *DeleteQuery) RunWith(runner Runner) *DeleteQuery {
+	newQuery := *s
+	newQuery.runner = runner
+	return &newQuery
+}
+
+// ExecContext builds the query and executes it with the runner set by RunWith
+func (s *DeleteQuery) ExecContext(ctx context.Context) (sql.Result, error) {
+	query, args, err := s.Build()
+	return execContext(ctx, s.runner, query, args, err)
+}
+
+// QueryContext builds the query and runs it with the runner set by RunWith
+func (s *DeleteQuery) QueryContext(ctx context.Context) (*sql.Rows, error) {
+	query, args, err := s.Build()
+	return queryContext(ctx, s.runner, query, args, err)
+}
+
+// QueryRowContext builds the query and runs it with the runner set by RunWith, the error of building the query is returned by Row.Scan
+func (s *DeleteQuery) QueryRowContext(ctx context.Context) *Row {
+	query, args, err := s.Build()
+	return queryRowContext(ctx, s.runner, query, args, err)
+}
+
+// Build builds the query and its arguments, placeholders are rendered for the query's dialect
 func (s *DeleteQuery) Build() (string, []interface{}, error) {
+	query, args, err := s.build()
+	if err != nil {
+		return "", nil, err
+	}
+	return rebind(orDefault(s.dialect), query), args, nil
+}
+
+// build builds the query with QUESTION placeholders
+func (s *DeleteQuery) build() (string, []interface{}, error) {
 	if s.table == "" {
 		return "", nil, errors.New(ErrTableIsEmpty)
 	}
 
+	q := s.quoter()
+	table, err := q.table(s.table)
+	if err != nil {
+		return "", nil, err
+	}
+	returningColumns, err := q.columns(s.returning)
+	if err != nil {
+		return "", nil, err
+	}
+	returning, output, err := buildReturning(orDefault(s.dialect), returningColumns, "DELETED")
+	if err != nil {
+		return "", nil, err
+	}
 	var query string
 	var args []interface{}
 
-	query = "DELETE FROM " + s.table
+	//
+	// add table name and joins
+	conditions, err := pkConditions(q, s.pk, s.conditions)
+	if err != nil {
+		return "", nil, err
+	}
+	d := orDefault(s.dialect)
+	switch {
+	case len(s.joins) == 0:
+		query = "DELETE FROM " + table + output
+	case d.Supports(FeatureDeleteUsing):
+		tables, tableArgs, joinConditions, err := buildJoinTables(s.dialect, s.joins, conditions)
+		if err != nil {
+			return "", nil, err
+		}
+		query = "DELETE FROM " + table + output + " USING " + tables
+		args = tableArgs
+		conditions = joinConditions
+	case d.Supports(FeatureDeleteJoin):
+		joins, joinArgs, err := buildJoins(s.dialect, s.joins)
+		if err != nil {
+			return "", nil, err
+		}
+		query = "DELETE " + tableAlias(table) + output + " FROM " + table + joins
+		args = joinArgs
+	default:
+		return "", nil, errors.New(ErrJoinNotSupported)
+	}
 
 	//
 	// check for where part
-	if len(s.conditions) > 0 {
-		var conditionsSlice []string
-		for _, condition := range s.conditions {
-			conditionsSlice = append(conditionsSlice, fmt.Sprintf("(%s)", condition.query))
-			args = append(args, condition.args...)
+	if len(conditions) > 0 {
+		conditions, conditionArgs, err := buildConditions(s.dialect, conditions)
+		if err != nil {
+			return "", nil, err
+		}
+		if conditions != "" {
+			query = query + " WHERE " + conditions
+			args = append(args, conditionArgs...)
+		}
+	}
+
+	//
+	// add returning columns
+	query = query + returning
+
+	//
+	// add order by and limit
+	if len(s.orderBy) > 0 || s.limit != nil {
+		if !d.Supports(FeatureUpdateLimit) || len(s.joins) > 0 {
+			return "", nil, errors.New(ErrOrderLimitNotSupported)
+		}
+		if len(s.orderBy) > 0 {
+			query = query + " ORDER BY " + buildOrderBy(s.orderBy)
+		}
+		if s.limit != nil {
+			query = query + " LIMIT " + strconv.FormatInt(s.limit.(int64), 10)
+		}
+	}
+
+	//
+	// add common table expressions
+	if len(s.ctes) > 0 {
+		if !d.Supports(FeatureWithUpdateDelete) {
+			return "", nil, errors.New(ErrWithNotSupported)
+		}
+		with, withArgs, err := buildWith(orDefault(s.dialect), s.ctes)
+		if err != nil {
+			return "", nil, err
 		}
-		query = query + " WHERE " + strings.Join(conditionsSlice, " AND ")
+		query = with + query
+		args = append(withArgs, args...)
 	}
 
 	// compare the number of args and ? in tableName
-	if len(args) != strings.Count(query, "?") {
+	if len(args) != countPlaceholders(s.dialect, query) {
 		return "", nil, errors.New(ErrWrongNumberOfArgs)
 	}
 
@@ -137,3 +137,143 @@ func TestDeleteQuery_Rebind(t *testing.T) {
 		})
 	}
 }
+
+func TestDeleteQuery_BuildDialect(t *testing.T) {
+	query, args, err := New(DialectPGX).Delete("table1").Where("id=?", 10).Where("email=? OR name=?", "a@b.c", "Omid").Build()
+	require.NoError(t, err)
+	require.Equal(t, "DELETE FROM table1 WHERE (id=$1) AND (email=$2 OR name=$3)", query)
+	require.Equal(t, []interface{}{10, "a@b.c", "Omid"}, args)
+
+	_, _, err = New(DialectPGX).Delete("table1").Where("id=? AND name=?", 10).Build()
+	require.Equal(t, errors.New(ErrWrongNumberOfArgs), err)
+}
+
+func TestDeleteQuery_BuildReturning(t *testing.T) {
+	query, args, err := New(DialectSqlite3).Delete("table1").Where("id=?", 1).Returning("*").Build()
+	require.NoError(t, err)
+	require.Equal(t, "DELETE FROM table1 WHERE (id=?) RETURNING *", query)
+	require.Equal(t, []interface{}{1}, args)
+
+	query, _, err = New(DialectSqlServer).Delete("table1").Where("id=?", 1).Returning("id").Build()
+	require.NoError(t, err)
+	require.Equal(t, "DELETE FROM table1 OUTPUT DELETED.id WHERE (id=@p1)", query)
+
+	_, _, err = New(DialectMySQL).Delete("table1").Returning("id").Build()
+	require.EqualError(t, err, ErrReturningNotSupported)
+}
+
+func TestDeleteQuery_BuildJoins(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     func(b *Builder) *DeleteQuery
+		dialect   Dialect
+		wantQuery string
+		wantArgs  []interface{}
+		wantErr   bool
+	}{
+		{
+			name:    "postgres using",
+			dialect: DialectPostgres,
+			query: func(b *Builder) *DeleteQuery {
+				return b.Delete("sessions s").Joins("users u", "u.id=s.user_id", JoinInner).Where("u.banned=?", true)
+			},
+			wantQuery: "DELETE FROM sessions s USING users u WHERE (u.id=s.user_id) AND (u.banned=$1)",
+			wantArgs:  []interface{}{true},
+		},
+		{
+			name:    "mysql join",
+			dialect: DialectMySQL,
+			query: func(b *Builder) *DeleteQuery {
+				return b.Delete("sessions s").Joins("users u", "u.id=s.user_id", JoinInner).Where("u.banned=?", true)
+			},
+			wantQuery: "DELETE s FROM sessions s JOIN users u ON u.id=s.user_id WHERE (u.banned=?)",
+			wantArgs:  []interface{}{true},
+		},
+		{
+			name:    "sql server join with output",
+			dialect: DialectSqlServer,
+			query: func(b *Builder) *DeleteQuery {
+				return b.Delete("sessions").Joins("users", "users.id=sessions.user_id", JoinInner).Returning("id")
+			},
+			wantQuery: "DELETE sessions OUTPUT DELETED.id FROM sessions JOIN users ON users.id=sessions.user_id",
+		},
+		{
+			name:    "sqlite",
+			dialect: DialectSqlite3,
+			query: func(b *Builder) *DeleteQuery {
+				return b.Delete("sessions s").Joins("users u", "u.id=s.user_id", JoinInner)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotQuery, gotArgs, err := tt.query(New(tt.dialect)).Build()
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.wantQuery, gotQuery)
+			require.Equal(t, tt.wantArgs, gotArgs)
+		})
+	}
+}
+
+func TestDeleteQuery_BuildOrderLimit(t *testing.T) {
+	query, args, err := New(DialectMySQL).Delete("logs").Where("created_at<?", "2020-01-01").
+		Order("id", OrderAsc).Limit(500).Build()
+	require.NoError(t, err)
+	require.Equal(t, "DELETE FROM logs WHERE (created_at<?) ORDER BY id ASC LIMIT 500", query)
+	require.Equal(t, []interface{}{"2020-01-01"}, args)
+
+	_, _, err = New(DialectSqlServer).Delete("logs").Order("id", OrderDesc).Build()
+	require.EqualError(t, err, ErrOrderLimitNotSupported)
+}
+
+func TestDeleteQuery_WherePK(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     *DeleteQuery
+		wantQuery string
+		wantArgs  []interface{}
+		wantErr   error
+	}{
+		{
+			name:      "pk and where",
+			query:     New(DialectPostgres).Delete("users").WherePK(&taggedUser{ID: 1, Name: "a"}).Where("status=?", "active"),
+			wantQuery: "DELETE FROM users WHERE (id=$1) AND (status=$2)",
+			wantArgs:  []interface{}{int64(1), "active"},
+		},
+		{
+			name:      "pk and or where",
+			query:     Delete("users").Where("status=?", "active").OrWhere("level=?", 1).WherePK(taggedUser{ID: 1}),
+			wantQuery: "DELETE FROM users WHERE (id=?) AND ((status=?) OR (level=?))",
+			wantArgs:  []interface{}{int64(1), "active", 1},
+		},
+		{
+			name:      "where pk replaces pk",
+			query:     New(DialectMySQL).Quote(QuoteAll).Delete("users").WherePK(taggedUser{ID: 1}).WherePK(taggedUser{ID: 2}),
+			wantQuery: "DELETE FROM `users` WHERE (`id`=?)",
+			wantArgs:  []interface{}{int64(2)},
+		},
+		{
+			name: "nil pk",
+			query: Delete("users").WherePK(struct {
+				ID *int64 `db:"id,pk"`
+			}{}),
+			wantErr: errors.New(ErrPrimaryKeyIsNull + ": id"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, args, err := tt.query.Build()
+			require.Equal(t, tt.wantErr, err)
+			require.Equal(t, tt.wantQuery, query)
+			require.Equal(t, tt.wantArgs, args)
+		})
+	}
+
+	require.Panics(t, func() { Delete("users").WherePK(taggedAudit{}) })
+	require.Panics(t, func() { Delete("users").WherePK(1) })
+}
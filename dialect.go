@@ -0,0 +1,266 @@
+package querybuilder
+
+import (
+	"errors"
+	"strings"
+)
+
+// Feature is a SQL capability which is not available on every database
+type Feature uint
+
+const (
+	// FeatureReturning is the RETURNING clause on INSERT, UPDATE and DELETE
+	FeatureReturning Feature = 1 << iota
+	// FeatureOnConflict is INSERT ... ON CONFLICT
+	FeatureOnConflict
+	// FeatureOnDuplicateKey is INSERT ... ON DUPLICATE KEY UPDATE
+	FeatureOnDuplicateKey
+	// FeatureMerge is the MERGE statement
+	FeatureMerge
+	// FeatureRowValues is row value comparison such as (a,b) > (?,?)
+	FeatureRowValues
+	// FeatureWithRecursive is the RECURSIVE keyword of recursive common table expressions
+	FeatureWithRecursive
+	// FeatureExcept is the EXCEPT set operator, dialects without it use MINUS
+	FeatureExcept
+	// FeatureOutput is the OUTPUT clause of SQL Server, used instead of RETURNING
+	FeatureOutput
+	// FeatureUpdateFrom is UPDATE t SET ... FROM other WHERE ...
+	FeatureUpdateFrom
+	// FeatureUpdateJoin is UPDATE t JOIN other ON ... SET ...
+	FeatureUpdateJoin
+	// FeatureUpdateFromJoin is UPDATE t SET ... FROM t JOIN other ON ...
+	FeatureUpdateFromJoin
+	// FeatureDeleteUsing is DELETE FROM t USING other WHERE ...
+	FeatureDeleteUsing
+	// FeatureDeleteJoin is DELETE t FROM t JOIN other ON ...
+	FeatureDeleteJoin
+	// FeatureUpdateLimit is ORDER BY and LIMIT on UPDATE and DELETE
+	FeatureUpdateLimit
+	// FeatureValuesTable is a VALUES list used as a table, such as USING (VALUES(?,?)) AS source(a,b)
+	FeatureValuesTable
+	// FeatureWithInsert is a WITH clause in front of INSERT, dialects without it put the WITH clause in front of the SELECT of INSERT ... SELECT
+	FeatureWithInsert
+	// FeatureWithUpdateDelete is a WITH clause in front of UPDATE and DELETE
+	FeatureWithUpdateDelete
+	// FeatureBackslashEscapes is backslash escapes like \' in '...' and "..." strings
+	FeatureBackslashEscapes
+	// FeatureHashComments is # comments to the end of the line
+	FeatureHashComments
+	// FeatureParenthesizedCompound is a parenthesized query as a member of a set operation, dialects without it select from the query as a derived table
+	FeatureParenthesizedCompound
+)
+
+// Dialect describes the SQL flavour spoken by a database driver.
+// Queries created from a Builder use their dialect to render SQL.
+type Dialect interface {
+	// Driver returns the name of the driver the dialect was made for
+	Driver() DriverName
+	// BindType returns the bindvar type of the dialect's placeholders
+	BindType() int
+	// QuoteIdentifier quotes a single table or column name
+	QuoteIdentifier(name string) string
+	// LimitOffset renders the pagination of a SELECT query, limit and offset are Sqlizers of a number or a placeholder, nil when not set.
+	// top is placed before the columns like TOP of SQL Server, clause is placed after ORDER BY. Either of them may be nil.
+	// ordered reports whether the query has an ORDER BY.
+	LimitOffset(limit, offset Sqlizer, ordered bool) (top Sqlizer, clause Sqlizer, err error)
+	// Supports reports whether the dialect supports the feature
+	Supports(feature Feature) bool
+	// MaxParams returns the maximum number of bind parameters of a single query, 0 means there is no limit
+	MaxParams() int
+}
+
+type paginationStyle int
+
+const (
+	// paginationLimitOffset is LIMIT m OFFSET n
+	paginationLimitOffset = iota
+	// paginationOffsetFetch is OFFSET n ROWS FETCH NEXT m ROWS ONLY, or FETCH FIRST m ROWS ONLY without offset
+	paginationOffsetFetch
+	// paginationTopOffsetFetch is TOP (m) without ORDER BY, otherwise OFFSET n ROWS FETCH NEXT m ROWS ONLY
+	paginationTopOffsetFetch
+)
+
+type dialect struct {
+	driver     DriverName
+	bindType   int
+	quoteStart string
+	quoteEnd   string
+	pagination paginationStyle
+	// noLimit is the LIMIT value used when only OFFSET is set, for databases which require LIMIT before OFFSET
+	noLimit   string
+	features  Feature
+	maxParams int
+}
+
+func (d *dialect) Driver() DriverName {
+	return d.driver
+}
+
+func (d *dialect) BindType() int {
+	return d.bindType
+}
+
+func (d *dialect) QuoteIdentifier(name string) string {
+	if d.quoteStart == "" {
+		return name
+	}
+	return d.quoteStart + strings.ReplaceAll(name, d.quoteEnd, d.quoteEnd+d.quoteEnd) + d.quoteEnd
+}
+
+func (d *dialect) LimitOffset(limit, offset Sqlizer, ordered bool) (Sqlizer, Sqlizer, error) {
+	if limit == nil && offset == nil {
+		return nil, nil, nil
+	}
+	switch d.pagination {
+	case paginationTopOffsetFetch:
+		if ordered {
+			return nil, offsetFetch(limit, offset), nil
+		}
+		if offset != nil {
+			return nil, nil, errors.New(ErrOrderByRequired)
+		}
+		return expr{sql: "TOP (?)", args: []interface{}{limit}}, nil, nil
+	case paginationOffsetFetch:
+		if offset == nil {
+			return nil, expr{sql: "FETCH FIRST ? ROWS ONLY", args: []interface{}{limit}}, nil
+		}
+		return nil, offsetFetch(limit, offset), nil
+	default:
+		if limit == nil {
+			if d.noLimit == "" {
+				return nil, expr{sql: "OFFSET ?", args: []interface{}{offset}}, nil
+			}
+			limit = Expr(d.noLimit)
+		}
+		if offset == nil {
+			return nil, expr{sql: "LIMIT ?", args: []interface{}{limit}}, nil
+		}
+		return nil, expr{sql: "LIMIT ? OFFSET ?", args: []interface{}{limit, offset}}, nil
+	}
+}
+
+// offsetFetch renders OFFSET n ROWS FETCH NEXT m ROWS ONLY, a missing offset is 0
+func offsetFetch(limit, offset Sqlizer) Sqlizer {
+	if offset == nil {
+		offset = Expr("0")
+	}
+	if limit == nil {
+		return expr{sql: "OFFSET ? ROWS", args: []interface{}{offset}}
+	}
+	return expr{sql: "OFFSET ? ROWS FETCH NEXT ? ROWS ONLY", args: []interface{}{offset, limit}}
+}
+
+func (d *dialect) Supports(feature Feature) bool {
+	return d.features&feature == feature
+}
+
+func (d *dialect) MaxParams() int {
+	return d.maxParams
+}
+
+func newPostgresDialect(driver DriverName) Dialect {
+	return &dialect{
+		driver:     driver,
+		bindType:   DOLLAR,
+		quoteStart: `"`,
+		quoteEnd:   `"`,
+		pagination: paginationLimitOffset,
+		features:   FeatureReturning | FeatureOnConflict | FeatureRowValues | FeatureWithRecursive | FeatureExcept | FeatureValuesTable | FeatureUpdateFrom | FeatureDeleteUsing | FeatureWithInsert | FeatureWithUpdateDelete | FeatureParenthesizedCompound,
+		maxParams:  65535,
+	}
+}
+
+func newOracleDialect(driver DriverName) Dialect {
+	return &dialect{
+		driver:     driver,
+		bindType:   NAMED,
+		quoteStart: `"`,
+		quoteEnd:   `"`,
+		pagination: paginationOffsetFetch,
+		features:   FeatureMerge | FeatureParenthesizedCompound,
+		maxParams:  65535,
+	}
+}
+
+// Built-in dialects, one for each supported driver
+var (
+	// DialectDefault renders PostgreSQL flavoured SQL with QUESTION placeholders and unquoted identifiers.
+	// It is used by the package level Select, Insert, Update and Delete functions.
+	DialectDefault Dialect = &dialect{
+		bindType:   QUESTION,
+		pagination: paginationLimitOffset,
+		features:   FeatureReturning | FeatureOnConflict | FeatureRowValues | FeatureWithRecursive | FeatureExcept | FeatureValuesTable | FeatureUpdateFrom | FeatureDeleteUsing | FeatureWithInsert | FeatureWithUpdateDelete | FeatureParenthesizedCompound,
+	}
+	DialectPostgres         = newPostgresDialect(DriverPostgres)
+	DialectPGX              = newPostgresDialect(DriverPGX)
+	DialectPqTimeout        = newPostgresDialect(DriverPqTimeout)
+	DialectCloudSqlPostgres = newPostgresDialect(DriverCloudSqlPostgres)
+	DialectMySQL            = Dialect(&dialect{
+		driver:     DriverMySQL,
+		bindType:   QUESTION,
+		quoteStart: "`",
+		quoteEnd:   "`",
+		pagination: paginationLimitOffset,
+		noLimit:    "18446744073709551615",
+		features:   FeatureOnDuplicateKey | FeatureRowValues | FeatureWithRecursive | FeatureExcept | FeatureUpdateJoin | FeatureDeleteJoin | FeatureUpdateLimit | FeatureWithUpdateDelete | FeatureBackslashEscapes | FeatureHashComments | FeatureParenthesizedCompound,
+		maxParams:  65535,
+	})
+	DialectSqlite3 = Dialect(&dialect{
+		driver:     DriverSqlite3,
+		bindType:   QUESTION,
+		quoteStart: `"`,
+		quoteEnd:   `"`,
+		pagination: paginationLimitOffset,
+		noLimit:    "-1",
+		features:   FeatureReturning | FeatureOnConflict | FeatureRowValues | FeatureWithRecursive | FeatureExcept | FeatureUpdateFrom | FeatureUpdateLimit | FeatureWithInsert | FeatureWithUpdateDelete,
+		maxParams:  999,
+	})
+	DialectOCI8      = newOracleDialect(DriverOCI8)
+	DialectORA       = newOracleDialect(DriverORA)
+	DialectGORACLE   = newOracleDialect(DriverGORACLE)
+	DialectSqlServer = Dialect(&dialect{
+		driver:     DriverSqlServer,
+		bindType:   AT,
+		quoteStart: "[",
+		quoteEnd:   "]",
+		pagination: paginationTopOffsetFetch,
+		features:   FeatureMerge | FeatureExcept | FeatureValuesTable | FeatureOutput | FeatureUpdateFromJoin | FeatureDeleteJoin | FeatureWithInsert | FeatureWithUpdateDelete | FeatureParenthesizedCompound,
+		maxParams:  2100,
+	})
+)
+
+// DialectFor returns the built-in dialect of a driver, or DialectDefault for unknown drivers
+func DialectFor(driverName DriverName) Dialect {
+	switch driverName {
+	case DriverPostgres:
+		return DialectPostgres
+	case DriverPGX:
+		return DialectPGX
+	case DriverPqTimeout:
+		return DialectPqTimeout
+	case DriverCloudSqlPostgres:
+		return DialectCloudSqlPostgres
+	case DriverMySQL:
+		return DialectMySQL
+	case DriverSqlite3:
+		return DialectSqlite3
+	case DriverOCI8:
+		return DialectOCI8
+	case DriverORA:
+		return DialectORA
+	case DriverGORACLE:
+		return DialectGORACLE
+	case DriverSqlServer:
+		return DialectSqlServer
+	}
+	return DialectDefault
+}
+
+// orDefault returns d, or DialectDefault when no dialect is set
+func orDefault(d Dialect) Dialect {
+	if d == nil {
+		return DialectDefault
+	}
+	return d
+}
@@ -0,0 +1,178 @@
+package querybuilder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDialectFor(t *testing.T) {
+	tests := []struct {
+		driver       DriverName
+		wantDialect  Dialect
+		wantBindType int
+	}{
+		{driver: DriverPostgres, wantDialect: DialectPostgres, wantBindType: DOLLAR},
+		{driver: DriverPGX, wantDialect: DialectPGX, wantBindType: DOLLAR},
+		{driver: DriverPqTimeout, wantDialect: DialectPqTimeout, wantBindType: DOLLAR},
+		{driver: DriverCloudSqlPostgres, wantDialect: DialectCloudSqlPostgres, wantBindType: DOLLAR},
+		{driver: DriverMySQL, wantDialect: DialectMySQL, wantBindType: QUESTION},
+		{driver: DriverSqlite3, wantDialect: DialectSqlite3, wantBindType: QUESTION},
+		{driver: DriverOCI8, wantDialect: DialectOCI8, wantBindType: NAMED},
+		{driver: DriverORA, wantDialect: DialectORA, wantBindType: NAMED},
+		{driver: DriverGORACLE, wantDialect: DialectGORACLE, wantBindType: NAMED},
+		{driver: DriverSqlServer, wantDialect: DialectSqlServer, wantBindType: AT},
+		{driver: "abcdefg", wantDialect: DialectDefault, wantBindType: QUESTION},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.driver), func(t *testing.T) {
+			d := DialectFor(tt.driver)
+			require.Equal(t, tt.wantDialect, d)
+			require.Equal(t, tt.wantBindType, d.BindType())
+			if d != DialectDefault {
+				require.Equal(t, tt.driver, d.Driver())
+				require.Equal(t, BindType(tt.driver), d.BindType())
+			}
+		})
+	}
+}
+
+func TestDialect_QuoteIdentifier(t *testing.T) {
+	require.Equal(t, `"order"`, DialectPostgres.QuoteIdentifier("order"))
+	require.Equal(t, `"a""b"`, DialectPostgres.QuoteIdentifier(`a"b`))
+	require.Equal(t, "`order`", DialectMySQL.QuoteIdentifier("order"))
+	require.Equal(t, "`a``b`", DialectMySQL.QuoteIdentifier("a`b"))
+	require.Equal(t, "[order]", DialectSqlServer.QuoteIdentifier("order"))
+	require.Equal(t, "[a]]b]", DialectSqlServer.QuoteIdentifier("a]b"))
+	require.Equal(t, `"order"`, DialectOCI8.QuoteIdentifier("order"))
+	require.Equal(t, "order", DialectDefault.QuoteIdentifier("order"))
+}
+
+func TestDialect_Supports(t *testing.T) {
+	require.True(t, DialectPostgres.Supports(FeatureReturning))
+	require.True(t, DialectPostgres.Supports(FeatureReturning|FeatureOnConflict))
+	require.False(t, DialectPostgres.Supports(FeatureOnDuplicateKey))
+	require.True(t, DialectMySQL.Supports(FeatureOnDuplicateKey))
+	require.False(t, DialectMySQL.Supports(FeatureReturning))
+	require.True(t, DialectSqlServer.Supports(FeatureMerge))
+	require.False(t, DialectSqlServer.Supports(FeatureRowValues))
+}
+
+func TestDialect_MaxParams(t *testing.T) {
+	require.Equal(t, 0, DialectDefault.MaxParams())
+	require.Equal(t, 65535, DialectPostgres.MaxParams())
+	require.Equal(t, 65535, DialectMySQL.MaxParams())
+	require.Equal(t, 999, DialectSqlite3.MaxParams())
+	require.Equal(t, 2100, DialectSqlServer.MaxParams())
+}
+
+func TestBuilder_SelectPagination(t *testing.T) {
+	tests := []struct {
+		name    string
+		dialect Dialect
+		query   func(b *Builder) *SelectQuery
+		want    string
+		wantErr bool
+	}{
+		{
+			name:    "postgres limit offset",
+			dialect: DialectPostgres,
+			query:   func(b *Builder) *SelectQuery { return b.Select("table1").Limit(10).Offset(20) },
+			want:    "SELECT * FROM table1 LIMIT 10 OFFSET 20",
+		},
+		{
+			name:    "postgres offset only",
+			dialect: DialectPostgres,
+			query:   func(b *Builder) *SelectQuery { return b.Select("table1").Offset(20) },
+			want:    "SELECT * FROM table1 OFFSET 20",
+		},
+		{
+			name:    "mysql offset only",
+			dialect: DialectMySQL,
+			query:   func(b *Builder) *SelectQuery { return b.Select("table1").Offset(20) },
+			want:    "SELECT * FROM table1 LIMIT 18446744073709551615 OFFSET 20",
+		},
+		{
+			name:    "sqlite offset only",
+			dialect: DialectSqlite3,
+			query:   func(b *Builder) *SelectQuery { return b.Select("table1").Offset(20) },
+			want:    "SELECT * FROM table1 LIMIT -1 OFFSET 20",
+		},
+		{
+			name:    "sqlserver limit offset",
+			dialect: DialectSqlServer,
+			query:   func(b *Builder) *SelectQuery { return b.Select("table1").Order("id", OrderAsc).Limit(10).Offset(20) },
+			want:    "SELECT * FROM table1 ORDER BY id ASC OFFSET 20 ROWS FETCH NEXT 10 ROWS ONLY",
+		},
+		{
+			name:    "oracle limit",
+			dialect: DialectOCI8,
+			query:   func(b *Builder) *SelectQuery { return b.Select("table1").Limit(10) },
+			want:    "SELECT * FROM table1 FETCH FIRST 10 ROWS ONLY",
+		},
+		{
+			name:    "oracle offset",
+			dialect: DialectOCI8,
+			query:   func(b *Builder) *SelectQuery { return b.Select("table1").Offset(20) },
+			want:    "SELECT * FROM table1 OFFSET 20 ROWS",
+		},
+		{
+			name:    "sqlserver top",
+			dialect: DialectSqlServer,
+			query:   func(b *Builder) *SelectQuery { return b.Select("table1").Columns("a,b").Limit(10) },
+			want:    "SELECT TOP (10) a,b FROM table1",
+		},
+		{
+			name:    "sqlserver offset without order by",
+			dialect: DialectSqlServer,
+			query:   func(b *Builder) *SelectQuery { return b.Select("table1").Limit(10).Offset(20) },
+			wantErr: true,
+		},
+		{
+			name:    "sqlserver top with union",
+			dialect: DialectSqlServer,
+			query:   func(b *Builder) *SelectQuery { return b.Select("table1").Union(b.Select("table2")).Limit(10) },
+			wantErr: true,
+		},
+		{
+			name:    "no pagination",
+			dialect: DialectSqlServer,
+			query:   func(b *Builder) *SelectQuery { return b.Select("table1") },
+			want:    "SELECT * FROM table1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, _, err := tt.query(New(tt.dialect)).Build()
+			if tt.wantErr {
+				require.EqualError(t, err, ErrOrderByRequired)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, query)
+		})
+	}
+}
+
+func TestBuilder_Rebind(t *testing.T) {
+	require.Equal(t, "SELECT * FROM table1 WHERE id=$1", New(DialectPostgres).Rebind("SELECT * FROM table1 WHERE id=?"))
+	require.Equal(t, "SELECT * FROM table1 WHERE id=@p1", New(DialectSqlServer).Rebind("SELECT * FROM table1 WHERE id=?"))
+	require.Equal(t, "SELECT * FROM table1 WHERE id=?", New(nil).Rebind("SELECT * FROM table1 WHERE id=?"))
+}
+
+func TestBuilder_BindPagination(t *testing.T) {
+	query, args, err := New(DialectPostgres).BindPagination().Select("table1").Where("a=?", 1).Limit(10).Offset(20).Build()
+	require.NoError(t, err)
+	require.Equal(t, "SELECT * FROM table1 WHERE (a=$1) LIMIT $2 OFFSET $3", query)
+	require.Equal(t, []interface{}{1, int64(10), int64(20)}, args)
+
+	query, args, err = New(DialectSqlServer).Select("table1").Where("a=?", 1).Order("id", OrderAsc).Limit(10).Offset(20).BindPagination().Build()
+	require.NoError(t, err)
+	require.Equal(t, "SELECT * FROM table1 WHERE (a=@p1) ORDER BY id ASC OFFSET @p2 ROWS FETCH NEXT @p3 ROWS ONLY", query)
+	require.Equal(t, []interface{}{1, int64(20), int64(10)}, args)
+
+	query, args, err = New(DialectSqlServer).BindPagination().Select("table1").Columns("a,?", 5).Limit(10).Build()
+	require.NoError(t, err)
+	require.Equal(t, "SELECT TOP (@p1) a,@p2 FROM table1", query)
+	require.Equal(t, []interface{}{int64(10), 5}, args)
+}
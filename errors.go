@@ -1,9 +1,34 @@
 package querybuilder
 
 const (
-	ErrTableIsEmpty          = "table name could not be empty"
-	ErrLimitNotInteger       = "LIMIT value is not an integer"
-	ErrOffsetNotInteger      = "OFFSET value is not an integer"
-	ErrWrongNumberOfArgs     = "wrong number of arguments"
-	ErrColumnValueMapIsEmpty = "column/value map is empty"
+	ErrTableIsEmpty           = "table name could not be empty"
+	ErrLimitNotInteger        = "LIMIT value is not an integer"
+	ErrOffsetNotInteger       = "OFFSET value is not an integer"
+	ErrWrongNumberOfArgs      = "wrong number of arguments"
+	ErrColumnValueMapIsEmpty  = "column/value map is empty"
+	ErrUnsupportedQueryType   = "query must be a query string or a Sqlizer"
+	ErrRowColumnsMismatch     = "all rows must have the same columns"
+	ErrConflictTargetIsEmpty  = "conflict target columns could not be empty"
+	ErrConflictActionIsEmpty  = "conflict action is missing, call DoNothing or DoUpdateSet"
+	ErrUpsertNotSupported     = "upsert is not supported by the dialect"
+	ErrReturningNotSupported  = "RETURNING is not supported by the dialect"
+	ErrColumnsIsEmpty         = "columns could not be empty"
+	ErrValuesAndSelect        = "values and a select could not be inserted together"
+	ErrColumnsAndValues       = "columns are only used with FromSelect, the columns of values are the keys of their map"
+	ErrJoinNotSupported       = "joins are not supported by the dialect for this query"
+	ErrJoinTypeNotSupported   = "only inner joins are supported by the dialect for this query"
+	ErrAdjustmentNotSet       = "Increment and Decrement can only be used as SET values"
+	ErrOrderLimitNotSupported = "ORDER BY and LIMIT are not supported by the dialect for this query"
+	ErrOrderByRequired        = "ORDER BY is required by the dialect for this pagination"
+	ErrInvalidCursor          = "cursor is invalid"
+	ErrKeysetWithCompound     = "keyset pagination can not be used with UNION, INTERSECT or EXCEPT, select from the compound query with SelectFrom instead"
+	ErrCursorMismatch         = "cursor must have one value for each ORDER BY column"
+	ErrInvalidIdentifier      = "invalid identifier"
+	ErrRunnerNotSet           = "runner is not set, call RunWith"
+	ErrScanDestination        = "destination must be a pointer to a struct or to a slice of structs"
+	ErrUnmappedColumn         = "column is not mapped to a struct field"
+	ErrPrimaryKeyIsEmpty      = "struct has no field tagged with pk"
+	ErrPrimaryKeyIsNull       = "primary key is null"
+	ErrWithNotSupported       = "common table expressions are not supported in front of this statement by the dialect"
+	ErrTooManyParams          = "a single row has more parameters than the maximum"
 )
@@ -0,0 +1,473 @@
+package querybuilder
+
+import (
+	"errors"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Sqlizer is implemented by everything which can be rendered to a SQL fragment with QUESTION placeholders and its arguments.
+// Columns, Where, Having and Joins accept a Sqlizer instead of a raw query string,
+// and a Sqlizer passed as an argument is inlined in place of its placeholder.
+type Sqlizer interface {
+	ToSql() (string, []interface{}, error)
+}
+
+// dialectSqlizer is implemented by the Sqlizers whose SQL depends on the dialect of the query they are rendered in,
+// like an expression whose placeholders are found with the lexer of the dialect
+type dialectSqlizer interface {
+	toSqlDialect(d Dialect) (string, []interface{}, error)
+}
+
+// renderSql renders s in a query of the dialect d, a nil d is DialectDefault
+func renderSql(d Dialect, s Sqlizer) (string, []interface{}, error) {
+	if ds, ok := s.(dialectSqlizer); ok {
+		return ds.toSqlDialect(d)
+	}
+	return s.ToSql()
+}
+
+type expr struct {
+	sql  string
+	args []interface{}
+}
+
+// Expr creates a raw SQL expression with its arguments
+func Expr(sql string, args ...interface{}) Sqlizer {
+	args, _ = unifyArgs(args...)
+	return expr{sql: sql, args: args}
+}
+
+// ToSql renders the expression. Arguments which are a Sqlizer are inlined in place of their placeholder,
+// the placeholders of the expression are then found with the SQL of DialectDefault,
+// or with the SQL of the dialect of the query the expression is used in.
+func (e expr) ToSql() (string, []interface{}, error) {
+	return e.toSqlDialect(nil)
+}
+
+func (e expr) toSqlDialect(d Dialect) (string, []interface{}, error) {
+	inline := false
+	for _, arg := range e.args {
+		if _, ok := arg.(Sqlizer); ok {
+			inline = true
+			break
+		}
+	}
+	if !inline {
+		return e.sql, e.args, nil
+	}
+	if countPlaceholders(d, e.sql) != len(e.args) {
+		return "", nil, errors.New(ErrWrongNumberOfArgs)
+	}
+
+	var sb strings.Builder
+	var args []interface{}
+	var err error
+	i := 0
+	scanQuery(d, e.sql, func(kind tokenKind, text string) {
+		if kind != tokenPlaceholder {
+			sb.WriteString(text)
+			return
+		}
+		arg := e.args[i]
+		i++
+		if sqlizer, ok := arg.(Sqlizer); ok {
+			sql, sqlArgs, sqlErr := renderSql(d, sqlizer)
+			if sqlErr != nil && err == nil {
+				err = sqlErr
+			}
+			sb.WriteString(sql)
+			args = append(args, sqlArgs...)
+			return
+		}
+		sb.WriteString(text)
+		args = append(args, arg)
+	})
+	if err != nil {
+		return "", nil, err
+	}
+	return sb.String(), args, nil
+}
+
+type errSqlizer struct {
+	err error
+}
+
+func (e errSqlizer) ToSql() (string, []interface{}, error) {
+	return "", nil, e.err
+}
+
+// toSqlizer converts the query parameter of Columns, Where, Having and Joins, a raw query string or a Sqlizer, to a Sqlizer
+func toSqlizer(query interface{}, args []interface{}) Sqlizer {
+	switch q := query.(type) {
+	case string:
+		return expr{sql: q, args: args}
+	case Sqlizer:
+		if len(args) > 0 {
+			return errSqlizer{err: errors.New(ErrWrongNumberOfArgs)}
+		}
+		return q
+	default:
+		return errSqlizer{err: errors.New(ErrUnsupportedQueryType)}
+	}
+}
+
+// tableSqlizer converts the table parameter of Joins to a Sqlizer.
+// A subquery aliased with As is rendered as a derived table, without the AS keyword which Oracle rejects for tables.
+func tableSqlizer(table interface{}) Sqlizer {
+	if a, ok := table.(aliasExpr); ok {
+		return derivedTable{query: a.query, alias: a.alias}
+	}
+	return toSqlizer(table, nil)
+}
+
+// Eq is an equality condition for each column/value of the map, joined with AND.
+// A nil value renders IS NULL and a slice value renders IN.
+type Eq map[string]interface{}
+
+func (eq Eq) ToSql() (string, []interface{}, error) {
+	return eq.toSqlDialect(nil)
+}
+
+func (eq Eq) toSqlDialect(d Dialect) (string, []interface{}, error) {
+	sql, args := columnValuesToSql(eq, false)
+	return expr{sql: sql, args: args}.toSqlDialect(d)
+}
+
+// Neq is an inequality condition for each column/value of the map, joined with AND.
+// A nil value renders IS NOT NULL and a slice value renders NOT IN.
+type Neq map[string]interface{}
+
+func (neq Neq) ToSql() (string, []interface{}, error) {
+	return neq.toSqlDialect(nil)
+}
+
+func (neq Neq) toSqlDialect(d Dialect) (string, []interface{}, error) {
+	sql, args := columnValuesToSql(neq, true)
+	return expr{sql: sql, args: args}.toSqlDialect(d)
+}
+
+// columnValuesToSql renders the conditions of Eq and Neq, Sqlizer values are left as arguments of a parenthesized placeholder
+func columnValuesToSql(columnValues map[string]interface{}, not bool) (string, []interface{}) {
+	columns := make([]string, 0, len(columnValues))
+	for column := range columnValues {
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+
+	var conditions []string
+	var args []interface{}
+	for _, column := range columns {
+		value := columnValues[column]
+		switch {
+		case value == nil && not:
+			conditions = append(conditions, column+" IS NOT NULL")
+		case value == nil:
+			conditions = append(conditions, column+" IS NULL")
+		case isListArg(value):
+			values, count := unifyArgs(value)
+			switch {
+			case count == 0 && not:
+				conditions = append(conditions, "1=1")
+			case count == 0:
+				conditions = append(conditions, "1=0")
+			case not:
+				conditions = append(conditions, column+" NOT IN ("+strings.TrimSuffix(strings.Repeat("?,", count), ",")+")")
+			default:
+				conditions = append(conditions, column+" IN ("+strings.TrimSuffix(strings.Repeat("?,", count), ",")+")")
+			}
+			args = append(args, values...)
+		case isSqlizer(value) && not:
+			conditions = append(conditions, column+" NOT IN (?)")
+			args = append(args, value)
+		case isSqlizer(value):
+			conditions = append(conditions, column+" IN (?)")
+			args = append(args, value)
+		case not:
+			conditions = append(conditions, column+"<>?")
+			args = append(args, value)
+		default:
+			conditions = append(conditions, column+"=?")
+			args = append(args, value)
+		}
+	}
+	if len(conditions) > 1 {
+		return "(" + strings.Join(conditions, " AND ") + ")", args
+	}
+	return strings.Join(conditions, ""), args
+}
+
+type comparison struct {
+	column   string
+	operator string
+	args     []interface{}
+}
+
+func (c comparison) ToSql() (string, []interface{}, error) {
+	return c.toSqlDialect(nil)
+}
+
+func (c comparison) toSqlDialect(d Dialect) (string, []interface{}, error) {
+	var sql string
+	switch c.operator {
+	case "IS NULL", "IS NOT NULL":
+		return c.column + " " + c.operator, nil, nil
+	case "BETWEEN":
+		sql = c.column + " BETWEEN " + placeholderFor(c.args[0]) + " AND " + placeholderFor(c.args[1])
+	case "LIKE", "NOT LIKE":
+		sql = c.column + " " + c.operator + " " + placeholderFor(c.args[0])
+	default:
+		sql = c.column + c.operator + placeholderFor(c.args[0])
+	}
+	return expr{sql: sql, args: c.args}.toSqlDialect(d)
+}
+
+// Gt creates a column>value condition
+func Gt(column string, value interface{}) Sqlizer {
+	return comparison{column: column, operator: ">", args: []interface{}{value}}
+}
+
+// Gte creates a column>=value condition
+func Gte(column string, value interface{}) Sqlizer {
+	return comparison{column: column, operator: ">=", args: []interface{}{value}}
+}
+
+// Lt creates a column<value condition
+func Lt(column string, value interface{}) Sqlizer {
+	return comparison{column: column, operator: "<", args: []interface{}{value}}
+}
+
+// Lte creates a column<=value condition
+func Lte(column string, value interface{}) Sqlizer {
+	return comparison{column: column, operator: "<=", args: []interface{}{value}}
+}
+
+// Like creates a column LIKE pattern condition
+func Like(column string, pattern interface{}) Sqlizer {
+	return comparison{column: column, operator: "LIKE", args: []interface{}{pattern}}
+}
+
+// NotLike creates a column NOT LIKE pattern condition
+func NotLike(column string, pattern interface{}) Sqlizer {
+	return comparison{column: column, operator: "NOT LIKE", args: []interface{}{pattern}}
+}
+
+// Between creates a column BETWEEN from AND to condition
+func Between(column string, from, to interface{}) Sqlizer {
+	return comparison{column: column, operator: "BETWEEN", args: []interface{}{from, to}}
+}
+
+// IsNull creates a column IS NULL condition
+func IsNull(column string) Sqlizer {
+	return comparison{column: column, operator: "IS NULL"}
+}
+
+// IsNotNull creates a column IS NOT NULL condition
+func IsNotNull(column string) Sqlizer {
+	return comparison{column: column, operator: "IS NOT NULL"}
+}
+
+type conjunction struct {
+	operator   string
+	conditions []Sqlizer
+}
+
+func (c conjunction) ToSql() (string, []interface{}, error) {
+	return c.toSqlDialect(nil)
+}
+
+func (c conjunction) toSqlDialect(d Dialect) (string, []interface{}, error) {
+	var conditions []string
+	var args []interface{}
+	for _, condition := range c.conditions {
+		sql, conditionArgs, err := renderSql(d, condition)
+		if err != nil {
+			return "", nil, err
+		}
+		if sql == "" {
+			continue
+		}
+		if !isSingleCondition(condition) {
+			sql = "(" + sql + ")"
+		}
+		conditions = append(conditions, sql)
+		args = append(args, conditionArgs...)
+	}
+	switch len(conditions) {
+	case 0:
+		return "", nil, nil
+	case 1:
+		return conditions[0], args, nil
+	default:
+		return "(" + strings.Join(conditions, " "+c.operator+" ") + ")", args, nil
+	}
+}
+
+// And joins the conditions with AND, empty conditions are skipped
+func And(conditions ...Sqlizer) Sqlizer {
+	return conjunction{operator: "AND", conditions: conditions}
+}
+
+// Or joins the conditions with OR, empty conditions are skipped
+func Or(conditions ...Sqlizer) Sqlizer {
+	return conjunction{operator: "OR", conditions: conditions}
+}
+
+// isSingleCondition tells whether a condition renders as a single comparison or a parenthesized block,
+// other conditions may contain AND or OR and are parenthesized to keep their precedence
+func isSingleCondition(condition Sqlizer) bool {
+	switch condition.(type) {
+	case Eq, Neq, comparison, conjunction, negation, *ConditionGroup:
+		return true
+	default:
+		return false
+	}
+}
+
+type negation struct {
+	condition Sqlizer
+}
+
+func (n negation) ToSql() (string, []interface{}, error) {
+	return n.toSqlDialect(nil)
+}
+
+func (n negation) toSqlDialect(d Dialect) (string, []interface{}, error) {
+	sql, args, err := renderSql(d, n.condition)
+	if err != nil || sql == "" {
+		return "", nil, err
+	}
+	return "NOT (" + sql + ")", args, nil
+}
+
+// Not negates the condition
+func Not(condition Sqlizer) Sqlizer {
+	return negation{condition: condition}
+}
+
+type aliasExpr struct {
+	query Sqlizer
+	alias string
+}
+
+func (a aliasExpr) ToSql() (string, []interface{}, error) {
+	return a.toSqlDialect(nil)
+}
+
+func (a aliasExpr) toSqlDialect(d Dialect) (string, []interface{}, error) {
+	sql, args, err := renderSql(d, a.query)
+	if err != nil {
+		return "", nil, err
+	}
+	return "(" + sql + ") AS " + a.alias, args, nil
+}
+
+type derivedTable struct {
+	query Sqlizer
+	alias string
+}
+
+func (t derivedTable) ToSql() (string, []interface{}, error) {
+	return t.toSqlDialect(nil)
+}
+
+func (t derivedTable) toSqlDialect(d Dialect) (string, []interface{}, error) {
+	sql, args, err := renderSql(d, t.query)
+	if err != nil {
+		return "", nil, err
+	}
+	return "(" + sql + ") " + t.alias, args, nil
+}
+
+type adjustment struct {
+	operator string
+	by       interface{}
+}
+
+// ToSql fails because an adjustment is rendered with its column, see assignmentSql
+func (a adjustment) ToSql() (string, []interface{}, error) {
+	return "", nil, errors.New(ErrAdjustmentNotSet)
+}
+
+// Increment is a SET value which adds by to the current value of the column, like counter=counter+?
+func Increment(by interface{}) Sqlizer {
+	return adjustment{operator: "+", by: by}
+}
+
+// Decrement is a SET value which subtracts by from the current value of the column, like counter=counter-?
+func Decrement(by interface{}) Sqlizer {
+	return adjustment{operator: "-", by: by}
+}
+
+// valueSql renders a value of VALUES or SET.
+// A subquery is parenthesized, other Sqlizers like Expr are inlined and any other value is a placeholder.
+func valueSql(d Dialect, value interface{}) (string, []interface{}, error) {
+	switch v := value.(type) {
+	case *SelectQuery:
+		sql, args, err := v.ToSql()
+		if err != nil {
+			return "", nil, err
+		}
+		return "(" + sql + ")", args, nil
+	case Sqlizer:
+		return renderSql(d, v)
+	default:
+		return "?", []interface{}{value}, nil
+	}
+}
+
+// rowValueSql renders each value of a row with valueSql and returns the args of the whole row
+func rowValueSql(d Dialect, row []interface{}) ([]string, []interface{}, error) {
+	sqls := make([]string, len(row))
+	var args []interface{}
+	for i, value := range row {
+		sql, valueArgs, err := valueSql(d, value)
+		if err != nil {
+			return nil, nil, err
+		}
+		sqls[i] = sql
+		args = append(args, valueArgs...)
+	}
+	return sqls, args, nil
+}
+
+// assignmentSql renders column=value of a SET, Increment and Decrement are applied to current, the reference to the current value of the column
+func assignmentSql(d Dialect, column string, current string, value interface{}) (string, []interface{}, error) {
+	if a, ok := value.(adjustment); ok {
+		sql, args, err := valueSql(d, a.by)
+		if err != nil {
+			return "", nil, err
+		}
+		return column + "=" + current + a.operator + sql, args, nil
+	}
+	sql, args, err := valueSql(d, value)
+	if err != nil {
+		return "", nil, err
+	}
+	return column + "=" + sql, args, nil
+}
+
+// placeholderFor returns the placeholder of a value, subqueries and expressions are parenthesized
+func placeholderFor(arg interface{}) string {
+	if isSqlizer(arg) {
+		return "(?)"
+	}
+	return "?"
+}
+
+func isSqlizer(arg interface{}) bool {
+	_, ok := arg.(Sqlizer)
+	return ok
+}
+
+// isListArg reports whether arg is a list of values which is expanded to one placeholder per element
+func isListArg(arg interface{}) bool {
+	if arg == nil {
+		return false
+	}
+	if _, ok := arg.([]byte); ok {
+		return false
+	}
+	return reflect.TypeOf(arg).Kind() == reflect.Slice
+}
@@ -0,0 +1,145 @@
+package querybuilder
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// queryBuilder is implemented by all query types
+type queryBuilder interface {
+	Build() (string, []interface{}, error)
+}
+
+func TestSqlizers(t *testing.T) {
+	tests := []struct {
+		name     string
+		sqlizer  Sqlizer
+		wantSql  string
+		wantArgs []interface{}
+		wantErr  error
+	}{
+		{name: "Eq", sqlizer: Eq{"a": 1}, wantSql: "a=?", wantArgs: []interface{}{1}},
+		{name: "Eq many", sqlizer: Eq{"b": 2, "a": 1}, wantSql: "(a=? AND b=?)", wantArgs: []interface{}{1, 2}},
+		{name: "Eq nil", sqlizer: Eq{"a": nil}, wantSql: "a IS NULL"},
+		{name: "Eq slice", sqlizer: Eq{"a": []int{1, 2}}, wantSql: "a IN (?,?)", wantArgs: []interface{}{1, 2}},
+		{name: "Eq empty slice", sqlizer: Eq{"a": []int{}}, wantSql: "1=0"},
+		{name: "Eq bytes", sqlizer: Eq{"a": []byte("ab")}, wantSql: "a=?", wantArgs: []interface{}{[]byte("ab")}},
+		{name: "Eq empty", sqlizer: Eq{}, wantSql: ""},
+		{name: "Neq", sqlizer: Neq{"a": 1}, wantSql: "a<>?", wantArgs: []interface{}{1}},
+		{name: "Neq nil", sqlizer: Neq{"a": nil}, wantSql: "a IS NOT NULL"},
+		{name: "Neq slice", sqlizer: Neq{"a": []string{"x"}}, wantSql: "a NOT IN (?)", wantArgs: []interface{}{"x"}},
+		{name: "Neq empty slice", sqlizer: Neq{"a": []string{}}, wantSql: "1=1"},
+		{name: "Gt", sqlizer: Gt("age", 18), wantSql: "age>?", wantArgs: []interface{}{18}},
+		{name: "Gte", sqlizer: Gte("age", 18), wantSql: "age>=?", wantArgs: []interface{}{18}},
+		{name: "Lt", sqlizer: Lt("age", 18), wantSql: "age<?", wantArgs: []interface{}{18}},
+		{name: "Lte", sqlizer: Lte("age", 18), wantSql: "age<=?", wantArgs: []interface{}{18}},
+		{name: "Like", sqlizer: Like("name", "O%"), wantSql: "name LIKE ?", wantArgs: []interface{}{"O%"}},
+		{name: "NotLike", sqlizer: NotLike("name", "O%"), wantSql: "name NOT LIKE ?", wantArgs: []interface{}{"O%"}},
+		{name: "Between", sqlizer: Between("age", 18, 65), wantSql: "age BETWEEN ? AND ?", wantArgs: []interface{}{18, 65}},
+		{name: "IsNull", sqlizer: IsNull("deleted_at"), wantSql: "deleted_at IS NULL"},
+		{name: "IsNotNull", sqlizer: IsNotNull("deleted_at"), wantSql: "deleted_at IS NOT NULL"},
+		{
+			name:     "And Or",
+			sqlizer:  And(Eq{"a": 1}, Or(Eq{"b": 2}, Gt("c", 3))),
+			wantSql:  "(a=? AND (b=? OR c>?))",
+			wantArgs: []interface{}{1, 2, 3},
+		},
+		{name: "And single", sqlizer: And(Eq{"a": 1}), wantSql: "a=?", wantArgs: []interface{}{1}},
+		{name: "And empty", sqlizer: And(), wantSql: ""},
+		{name: "Or skips empty", sqlizer: Or(Eq{}, IsNull("a")), wantSql: "a IS NULL"},
+		{name: "Or with Expr", sqlizer: Or(Expr("a=1 AND b=?", 2), IsNull("c")), wantSql: "((a=1 AND b=?) OR c IS NULL)", wantArgs: []interface{}{2}},
+		{
+			name:     "And with group",
+			sqlizer:  And((&ConditionGroup{}).Where("a=1").OrWhere("b=2"), Eq{"c": 3}),
+			wantSql:  "(((a=1) OR (b=2)) AND c=?)",
+			wantArgs: []interface{}{3},
+		},
+		{
+			name:     "And with Sqlizer",
+			sqlizer:  And(orSqlizer{}, Eq{"c": 3}),
+			wantSql:  "((a=1 OR b=2) AND c=?)",
+			wantArgs: []interface{}{3},
+		},
+		{name: "Or with Eq many", sqlizer: Or(Eq{"a": 1, "b": 2}, IsNull("c")), wantSql: "((a=? AND b=?) OR c IS NULL)", wantArgs: []interface{}{1, 2}},
+		{name: "Not", sqlizer: Not(Or(Eq{"a": 1}, Eq{"b": 2})), wantSql: "NOT ((a=? OR b=?))", wantArgs: []interface{}{1, 2}},
+		{name: "Not empty", sqlizer: Not(And()), wantSql: ""},
+		{name: "Expr", sqlizer: Expr("a IN (?)", []int{1, 2}), wantSql: "a IN (?)", wantArgs: []interface{}{1, 2}},
+		{name: "Expr with Sqlizer arg", sqlizer: Expr("a=? OR ?", 1, Gt("b", 2)), wantSql: "a=? OR b>?", wantArgs: []interface{}{1, 2}},
+		{name: "Expr with Sqlizer arg and wrong args", sqlizer: Expr("a=?", 1, Gt("b", 2)), wantErr: errors.New(ErrWrongNumberOfArgs)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql, args, err := tt.sqlizer.ToSql()
+			require.Equal(t, tt.wantErr, err)
+			require.Equal(t, tt.wantSql, sql)
+			require.Equal(t, tt.wantArgs, args)
+		})
+	}
+}
+
+// orSqlizer is a Sqlizer outside of the package which renders an OR without parentheses
+type orSqlizer struct{}
+
+func (orSqlizer) ToSql() (string, []interface{}, error) {
+	return "a=1 OR b=2", nil, nil
+}
+
+func TestSqlizerConditions(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     queryBuilder
+		wantQuery string
+		wantArgs  []interface{}
+		wantErr   error
+	}{
+		{
+			name:      "select",
+			query:     Select("table1").Where(Eq{"status": "active"}).Where("age>?", 18).Where(Or(Like("name", "O%"), IsNull("name"))),
+			wantQuery: "SELECT * FROM table1 WHERE (status=?) AND (age>?) AND ((name LIKE ? OR name IS NULL))",
+			wantArgs:  []interface{}{"active", 18, "O%"},
+		},
+		{
+			name:      "select empty condition",
+			query:     Select("table1").Where(And()),
+			wantQuery: "SELECT * FROM table1",
+		},
+		{
+			name:      "select having and join",
+			query:     Select("table1").Joins("table2", Expr("table1.id=table2.t_id AND table2.kind=?", "k"), JoinLeft).Group("c1").Having(Gt("SUM(c2)", 10)),
+			wantQuery: "SELECT * FROM table1 LEFT JOIN table2 ON table1.id=table2.t_id AND table2.kind=? GROUP BY c1 HAVING (SUM(c2)>?)",
+			wantArgs:  []interface{}{"k", 10},
+		},
+		{
+			name:      "update",
+			query:     New(DialectPostgres).Update("table1").MapValues(map[string]interface{}{"a": 1}).Where(Eq{"id": []int{1, 2}}),
+			wantQuery: "UPDATE table1 SET a=$1 WHERE (id IN ($2,$3))",
+			wantArgs:  []interface{}{1, 1, 2},
+		},
+		{
+			name:      "delete",
+			query:     Delete("table1").Where(Not(Eq{"id": 1})),
+			wantQuery: "DELETE FROM table1 WHERE (NOT (id=?))",
+			wantArgs:  []interface{}{1},
+		},
+		{
+			name:    "Sqlizer with args",
+			query:   Delete("table1").Where(Eq{"id": 1}, 2),
+			wantErr: errors.New(ErrWrongNumberOfArgs),
+		},
+		{
+			name:    "unsupported condition",
+			query:   Delete("table1").Where(10),
+			wantErr: errors.New(ErrUnsupportedQueryType),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, args, err := tt.query.Build()
+			require.Equal(t, tt.wantErr, err)
+			require.Equal(t, tt.wantQuery, query)
+			require.Equal(t, tt.wantArgs, args)
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package querybuilder
+
+import (
+	"context"
+	"reflect"
+)
+
+// SelectOf starts a SELECT query of the columns of T, a struct whose fields are mapped to columns like in StructValues
+func SelectOf[T any](name string) *SelectQuery {
+	return selectOf[T](Select(name))
+}
+
+// SelectOfWith is SelectOf for the queries of a Builder
+func SelectOfWith[T any](b *Builder, name string) *SelectQuery {
+	return selectOf[T](b.Select(name))
+}
+
+func selectOf[T any](query *SelectQuery) *SelectQuery {
+	return query.Columns(reflect.TypeOf((*T)(nil)).Elem())
+}
+
+// Get runs query and returns its first row as a T, see Scan. If runner is nil, the runner set by RunWith is used.
+func Get[T any](ctx context.Context, runner Runner, query *SelectQuery) (T, error) {
+	var dest T
+	if err := query.Scan(ctx, runner, &dest); err != nil {
+		var zero T
+		return zero, err
+	}
+	return dest, nil
+}
+
+// List runs query and returns its rows as a slice of T, see ScanAll. If runner is nil, the runner set by RunWith is used.
+func List[T any](ctx context.Context, runner Runner, query *SelectQuery) ([]T, error) {
+	var dest []T
+	if err := query.ScanAll(ctx, runner, &dest); err != nil {
+		return nil, err
+	}
+	return dest, nil
+}
@@ -0,0 +1,76 @@
+package querybuilder
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelectOf(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     *SelectQuery
+		wantQuery string
+	}{
+		{
+			name:      "default",
+			query:     SelectOf[scanUser]("users").Where("id=?", 1),
+			wantQuery: "SELECT id,name,email,city,Country FROM users WHERE (id=?)",
+		},
+		{
+			name:      "builder",
+			query:     SelectOfWith[scanUser](New(DialectPostgres), "users").Where("id=?", 1),
+			wantQuery: "SELECT id,name,email,city,Country FROM users WHERE (id=$1)",
+		},
+		{
+			name:      "quoted",
+			query:     SelectOfWith[scanAddress](New(DialectMySQL).Quote(QuoteAll), "users"),
+			wantQuery: "SELECT `city`,`Country` FROM `users`",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, _, err := tt.query.Build()
+			require.NoError(t, err)
+			require.Equal(t, tt.wantQuery, query)
+		})
+	}
+
+	require.Panics(t, func() { SelectOf[int64]("users") })
+}
+
+func TestGetAndList(t *testing.T) {
+	db, backend := openFakeDB(t, []string{"id", "name", "email", "city", "Country"},
+		[]driver.Value{int64(1), "a", nil, "Berlin", "DE"},
+		[]driver.Value{int64(2), "b", nil, "Paris", "FR"})
+	ctx := context.Background()
+
+	user, err := Get[scanUser](ctx, db, SelectOf[scanUser]("users").Where("id=?", 1))
+	require.NoError(t, err)
+	require.Equal(t, scanUser{ID: 1, Name: "a", scanAddress: scanAddress{City: "Berlin", Country: "DE"}}, user)
+
+	users, err := List[scanUser](ctx, nil, SelectOfWith[scanUser](New(DialectPostgres).RunWith(db), "users"))
+	require.NoError(t, err)
+	require.Len(t, users, 2)
+	require.Equal(t, "Paris", users[1].City)
+
+	require.Equal(t, []string{
+		"SELECT id,name,email,city,Country FROM users WHERE (id=?)",
+		"SELECT id,name,email,city,Country FROM users",
+	}, backend.queries)
+}
+
+func TestGetAndList_Errors(t *testing.T) {
+	db, _ := openFakeDB(t, []string{"id"})
+	ctx := context.Background()
+
+	_, err := Get[scanUser](ctx, db, SelectOf[scanUser]("users"))
+	require.Equal(t, sql.ErrNoRows, err)
+
+	users, err := List[scanUser](ctx, nil, SelectOf[scanUser]("users"))
+	require.EqualError(t, err, ErrRunnerNotSet)
+	require.Nil(t, users)
+}
@@ -0,0 +1,51 @@
+package querybuilder
+
+// ConditionGroup is a block of conditions which is rendered in parentheses, see WhereGroup
+type ConditionGroup struct {
+	conditions []whereClause
+}
+
+// Where adds a condition joined with AND
+func (g *ConditionGroup) Where(query interface{}, args ...interface{}) *ConditionGroup {
+	args, _ = unifyArgs(args...)
+	g.conditions = append(g.conditions, whereClause{condition: toSqlizer(query, args)})
+	return g
+}
+
+// OrWhere adds a condition joined with OR
+func (g *ConditionGroup) OrWhere(query interface{}, args ...interface{}) *ConditionGroup {
+	args, _ = unifyArgs(args...)
+	g.conditions = append(g.conditions, whereClause{condition: toSqlizer(query, args), or: true})
+	return g
+}
+
+// WhereGroup adds a nested group of conditions joined with AND
+func (g *ConditionGroup) WhereGroup(fn func(g *ConditionGroup)) *ConditionGroup {
+	g.conditions = append(g.conditions, whereClause{condition: newConditionGroup(fn)})
+	return g
+}
+
+// OrWhereGroup adds a nested group of conditions joined with OR
+func (g *ConditionGroup) OrWhereGroup(fn func(g *ConditionGroup)) *ConditionGroup {
+	g.conditions = append(g.conditions, whereClause{condition: newConditionGroup(fn), or: true})
+	return g
+}
+
+// ToSql renders the conditions of the group in parentheses, an empty group renders nothing
+func (g *ConditionGroup) ToSql() (string, []interface{}, error) {
+	return g.toSqlDialect(nil)
+}
+
+func (g *ConditionGroup) toSqlDialect(d Dialect) (string, []interface{}, error) {
+	sql, args, err := buildConditions(d, g.conditions)
+	if err != nil || sql == "" {
+		return "", nil, err
+	}
+	return "(" + sql + ")", args, nil
+}
+
+func newConditionGroup(fn func(g *ConditionGroup)) *ConditionGroup {
+	g := &ConditionGroup{}
+	fn(g)
+	return g
+}
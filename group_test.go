@@ -0,0 +1,91 @@
+package querybuilder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConditionGroup_ToSql(t *testing.T) {
+	sql, args, err := (&ConditionGroup{}).Where("a=?", 1).OrWhere("b=?", 2).ToSql()
+	require.NoError(t, err)
+	require.Equal(t, "((a=?) OR (b=?))", sql)
+	require.Equal(t, []interface{}{1, 2}, args)
+
+	sql, args, err = (&ConditionGroup{}).ToSql()
+	require.NoError(t, err)
+	require.Equal(t, "", sql)
+	require.Nil(t, args)
+}
+
+func TestOrWhereAndWhereGroup(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     queryBuilder
+		wantQuery string
+		wantArgs  []interface{}
+	}{
+		{
+			name:      "select OrWhere",
+			query:     Select("table1").Where("a=?", 1).OrWhere("b=?", 2),
+			wantQuery: "SELECT * FROM table1 WHERE (a=?) OR (b=?)",
+			wantArgs:  []interface{}{1, 2},
+		},
+		{
+			name:      "select OrWhere first",
+			query:     Select("table1").OrWhere("a=?", 1).Where("b=?", 2),
+			wantQuery: "SELECT * FROM table1 WHERE (a=?) AND (b=?)",
+			wantArgs:  []interface{}{1, 2},
+		},
+		{
+			name: "select nested groups",
+			query: New(DialectPostgres).Select("table1").
+				WhereGroup(func(g *ConditionGroup) {
+					g.Where("a=?", 1).WhereGroup(func(g *ConditionGroup) {
+						g.Where("b=?", 2).OrWhere(Eq{"c": 3})
+					})
+				}).
+				OrWhereGroup(func(g *ConditionGroup) {
+					g.Where("d=?", 4).Where("e=?", 5)
+				}),
+			wantQuery: "SELECT * FROM table1 WHERE ((a=$1) AND ((b=$2) OR (c=$3))) OR ((d=$4) AND (e=$5))",
+			wantArgs:  []interface{}{1, 2, 3, 4, 5},
+		},
+		{
+			name: "select group with OrWhereGroup",
+			query: Select("table1").Where("a=?", 1).WhereGroup(func(g *ConditionGroup) {
+				g.WhereGroup(func(g *ConditionGroup) { g.Where("b=?", 2) }).OrWhereGroup(func(g *ConditionGroup) { g.Where("c=?", 3) })
+			}),
+			wantQuery: "SELECT * FROM table1 WHERE (a=?) AND (((b=?)) OR ((c=?)))",
+			wantArgs:  []interface{}{1, 2, 3},
+		},
+		{
+			name:      "select empty group",
+			query:     Select("table1").Where("a=?", 1).OrWhereGroup(func(g *ConditionGroup) {}),
+			wantQuery: "SELECT * FROM table1 WHERE (a=?)",
+			wantArgs:  []interface{}{1},
+		},
+		{
+			name: "update",
+			query: Update("table1").MapValues(map[string]interface{}{"f": 0}).Where("a=?", 1).WhereGroup(func(g *ConditionGroup) {
+				g.Where("b=?", 2).OrWhere("c=?", 3)
+			}),
+			wantQuery: "UPDATE table1 SET f=? WHERE (a=?) AND ((b=?) OR (c=?))",
+			wantArgs:  []interface{}{0, 1, 2, 3},
+		},
+		{
+			name:      "delete",
+			query:     Delete("table1").Where("a=?", 1).OrWhere("b=?", 2).OrWhereGroup(func(g *ConditionGroup) { g.Where("c=?", 3).Where("d=?", 4) }),
+			wantQuery: "DELETE FROM table1 WHERE (a=?) OR (b=?) OR ((c=?) AND (d=?))",
+			wantArgs:  []interface{}{1, 2, 3, 4},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, args, err := tt.query.Build()
+			require.NoError(t, err)
+			require.Equal(t, tt.wantQuery, query)
+			require.Equal(t, tt.wantArgs, args)
+		})
+	}
+}
@@ -1,58 +1,450 @@
 package querybuilder
 
 import (
+	"context"
+	"database/sql"
 	"errors"
+	"fmt"
 	"log"
+	"reflect"
 	"strings"
 )
 
 type InsertQuery struct {
-	table               string
-	indexedColumnValues IndexedColumnValues
+	dialect    Dialect
+	ctes       []withClause
+	table      string
+	rows       []IndexedColumnValues
+	columns    []string
+	fromSelect *SelectQuery
+	quoteMode  QuoteMode
+	runner     Runner
+	conflict   *conflictClause
+	returning  []string
 }
 
 // MapValues gets columns and values,
-// Enter Column/Values as a key/value map
+// Enter Column/Values as a key/value map, the columns are raw SQL unless the Builder quotes them, see Builder.Quote
 func (s *InsertQuery) MapValues(columnValues map[string]interface{}) *InsertQuery {
 	newQuery := *s
-	newQuery.indexedColumnValues = mapToIndexColumnValue(columnValues)
+	newQuery.rows = []IndexedColumnValues{mapToIndexColumnValue(columnValues)}
 	return &newQuery
 }
 
 // StructValues gets and struct and extract column/values,
+// fields tagged with the readonly or -insert options, and zero fields tagged with omitempty or default are skipped
 func (s *InsertQuery) StructValues(structure interface{}) *InsertQuery {
 	newQuery := *s
-	m, err := structToMap(structure)
+	m, _, err := structToMap(structure, structInsert)
 	if err != nil {
 		log.Panic(err)
 	}
-	newQuery.indexedColumnValues = m
+	newQuery.rows = []IndexedColumnValues{m}
 	return &newQuery
 }
 
+// Rows gets the columns and values of many rows, each row as a key/value map.
+// All rows must have the same columns.
+func (s *InsertQuery) Rows(rows []map[string]interface{}) *InsertQuery {
+	newQuery := *s
+	newQuery.rows = make([]IndexedColumnValues, len(rows))
+	for i, row := range rows {
+		newQuery.rows[i] = mapToIndexColumnValue(row)
+	}
+	return &newQuery
+}
+
+// StructsValues gets a slice of structs and extract the column/values of each element as a row.
+// All rows must have the same columns.
+func (s *InsertQuery) StructsValues(structures interface{}) *InsertQuery {
+	v := reflect.ValueOf(structures)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		log.Panic(errors.New("unexpected type"))
+	}
+	newQuery := *s
+	newQuery.rows = make([]IndexedColumnValues, v.Len())
+	for i := 0; i < v.Len(); i++ {
+		m, _, err := structToMap(v.Index(i).Interface(), structInsert)
+		if err != nil {
+			log.Panic(err)
+		}
+		newQuery.rows[i] = m
+	}
+	return &newQuery
+}
+
+// AddRow adds a row to the query, enter Column/Values as a key/value map.
+// All rows must have the same columns.
+func (s *InsertQuery) AddRow(columnValues map[string]interface{}) *InsertQuery {
+	newQuery := *s
+	newQuery.rows = append(newQuery.rows[:len(newQuery.rows):len(newQuery.rows)], mapToIndexColumnValue(columnValues))
+	return &newQuery
+}
+
+// Columns sets the columns filled by FromSelect, building the query fails if it's used with values
+func (s *InsertQuery) Columns(columns ...string) *InsertQuery {
+	newQuery := *s
+	newQuery.columns = columns
+	return &newQuery
+}
+
+// FromSelect inserts the rows of the select query instead of values, the args of the select are kept.
+// The select's columns must match the columns set with Columns.
+func (s *InsertQuery) FromSelect(query *SelectQuery) *InsertQuery {
+	newQuery := *s
+	newQuery.fromSelect = query
+	return &newQuery
+}
+
+// OnConflict sets the columns of the unique constraint which triggers the conflict action.
+// The columns are required by every dialect except MySQL.
+func (s *InsertQuery) OnConflict(columns ...string) *InsertQuery {
+	newQuery := *s
+	conflict := newQuery.conflictClause()
+	conflict.columns = columns
+	newQuery.conflict = conflict
+	return &newQuery
+}
+
+// DoNothing keeps the existing row on conflict
+func (s *InsertQuery) DoNothing() *InsertQuery {
+	newQuery := *s
+	conflict := newQuery.conflictClause()
+	conflict.doNothing = true
+	conflict.set = nil
+	newQuery.conflict = conflict
+	return &newQuery
+}
+
+// DoUpdateSet updates the existing row on conflict, Enter Column/Values as a key/value map.
+// Use Excluded(column) as a value to reference the row proposed for insertion.
+func (s *InsertQuery) DoUpdateSet(columnValues map[string]interface{}) *InsertQuery {
+	newQuery := *s
+	conflict := newQuery.conflictClause()
+	conflict.doNothing = false
+	conflict.set = mapToIndexColumnValue(columnValues)
+	newQuery.conflict = conflict
+	return &newQuery
+}
+
+// OnDuplicateKeyUpdate is the same as DoUpdateSet, named after the MySQL clause
+func (s *InsertQuery) OnDuplicateKeyUpdate(columnValues map[string]interface{}) *InsertQuery {
+	return s.DoUpdateSet(columnValues)
+}
+
+// conflictClause returns a copy of the query's conflict clause, or an empty one
+func (s *InsertQuery) conflictClause() *conflictClause {
+	if s.conflict == nil {
+		return &conflictClause{}
+	}
+	conflict := *s.conflict
+	return &conflict
+}
+
+// Returning sets the columns returned by the query, rendered as RETURNING or, on SQL Server, as OUTPUT INSERTED.column
+func (s *InsertQuery) Returning(columns ...string) *InsertQuery {
+	newQuery := *s
+	newQuery.returning = columns
+	return &newQuery
+}
+
+// With adds a common table expression, query is a raw query string with its args or a Sqlizer like a SelectQuery
+func (s *InsertQuery) With(name string, query interface{}, args ...interface{}) *InsertQuery {
+	args, _ = unifyArgs(args...)
+	cte := withClause{
+		name:  name,
+		query: toSqlizer(query, args),
+	}
+	newQuery := *s
+	newQuery.ctes = append(newQuery.ctes, cte)
+	return &newQuery
+}
+
+// WithRecursive adds a recursive common table expression with its columns
+func (s *InsertQuery) WithRecursive(name string, columns string, query interface{}, args ...interface{}) *InsertQuery {
+	args, _ = unifyArgs(args...)
+	cte := withClause{
+		name:      name,
+		columns:   columns,
+		query:     toSqlizer(query, args),
+		recursive: true,
+	}
+	newQuery := *s
+	newQuery.ctes = append(newQuery.ctes, cte)
+	return &newQuery
+}
+
+// quoter returns the identifierQuoter of the query's dialect and quote mode
+func (s *InsertQuery) quoter() identifierQuoter {
+	return identifierQuoter{dialect: s.dialect, mode: s.quoteMode}
+}
+
+// RunWith sets the runner which executes the query, like a *sql.DB, *sql.Tx or *sql.Conn
+func (s *InsertQuery) RunWith(runner Runner) *InsertQuery {
+	newQuery := *s
+	newQuery.runner = runner
+	return &newQuery
+}
+
+// ExecContext builds the query and executes it with the runner set by RunWith
+func (s *InsertQuery) ExecContext(ctx context.Context) (sql.Result, error) {
+	query, args, err := s.Build()
+	return execContext(ctx, s.runner, query, args, err)
+}
+
+// QueryContext builds the query and runs it with the runner set by RunWith
+func (s *InsertQuery) QueryContext(ctx context.Context) (*sql.Rows, error) {
+	query, args, err := s.Build()
+	return queryContext(ctx, s.runner, query, args, err)
+}
+
+// QueryRowContext builds the query and runs it with the runner set by RunWith, the error of building the query is returned by Row.Scan
+func (s *InsertQuery) QueryRowContext(ctx context.Context) *Row {
+	query, args, err := s.Build()
+	return queryRowContext(ctx, s.runner, query, args, err)
+}
+
+// Build builds the query and its arguments, placeholders are rendered for the query's dialect
 func (s *InsertQuery) Build() (string, []interface{}, error) {
+	query, args, err := s.build()
+	if err != nil {
+		return "", nil, err
+	}
+	return rebind(orDefault(s.dialect), query), args, nil
+}
+
+// Batch is one query and its arguments of a split INSERT
+type Batch struct {
+	Query string
+	Args  []interface{}
+}
+
+// BuildBatches splits the rows into as many queries as needed to keep each one within maxParams bind parameters.
+// If maxParams is 0 or less, the maximum of the query's dialect is used.
+// The parameters of common table expressions and of the conflict action are repeated in every batch.
+func (s *InsertQuery) BuildBatches(maxParams int) ([]Batch, error) {
+	if s.fromSelect != nil {
+		query, args, err := s.Build()
+		if err != nil {
+			return nil, err
+		}
+		return []Batch{{Query: query, Args: args}}, nil
+	}
+	if maxParams <= 0 {
+		maxParams = orDefault(s.dialect).MaxParams()
+	}
+	_, values, err := rowValues(s.rows)
+	if err != nil {
+		return nil, err
+	}
+	rowParams := make([]int, len(values))
+	for i, row := range values {
+		_, rowArgs, err := rowValueSql(s.dialect, row)
+		if err != nil {
+			return nil, err
+		}
+		rowParams[i] = len(rowArgs)
+	}
+	// the parameters which are not part of the rows are repeated in every batch
+	fixedParams := 0
+	if maxParams > 0 {
+		firstRow := *s
+		firstRow.rows = s.rows[:1:1]
+		_, firstRowArgs, err := firstRow.build()
+		if err != nil {
+			return nil, err
+		}
+		fixedParams = len(firstRowArgs) - rowParams[0]
+	}
+
+	// cut the rows into batches which fit in maxParams
+	var ends []int
+	params := fixedParams
+	for i, n := range rowParams {
+		if maxParams > 0 && fixedParams+n > maxParams {
+			return nil, errors.New(ErrTooManyParams)
+		}
+		if maxParams > 0 && params+n > maxParams {
+			ends = append(ends, i)
+			params = fixedParams
+		}
+		params += n
+	}
+	ends = append(ends, len(rowParams))
+
+	batches := make([]Batch, len(ends))
+	start := 0
+	for i, end := range ends {
+		batchQuery := *s
+		batchQuery.rows = s.rows[start:end:end]
+		query, args, err := batchQuery.Build()
+		if err != nil {
+			return nil, err
+		}
+		batches[i] = Batch{Query: query, Args: args}
+		start = end
+	}
+	return batches, nil
+}
+
+// build builds the query with QUESTION placeholders
+func (s *InsertQuery) build() (string, []interface{}, error) {
 	if s.table == "" {
 		return "", nil, errors.New(ErrTableIsEmpty)
 	}
-	if len(s.indexedColumnValues) == 0 {
-		return "", nil, errors.New(ErrColumnValueMapIsEmpty)
+	q := s.quoter()
+	table, err := q.table(s.table)
+	if err != nil {
+		return "", nil, err
+	}
+	returningColumns, err := q.columns(s.returning)
+	if err != nil {
+		return "", nil, err
+	}
+	returning, output, err := buildReturning(orDefault(s.dialect), returningColumns, "INSERTED")
+	if err != nil {
+		return "", nil, err
 	}
 	var query string
+	var args []interface{}
+	var columns []string
+	var values [][]interface{}
 
-	args := make([]interface{}, len(s.indexedColumnValues))
+	if s.fromSelect != nil {
+		if len(s.rows) > 0 {
+			return "", nil, errors.New(ErrValuesAndSelect)
+		}
+		selectQuery, selectArgs, err := s.selectSource(orDefault(s.dialect))
+		if err != nil {
+			return "", nil, err
+		}
+		columns, err = q.columns(s.columns)
+		if err != nil {
+			return "", nil, err
+		}
+		args = selectArgs
 
-	// make column slice
-	columns := make([]string, len(s.indexedColumnValues))
+		//
+		// add table name
+		query = "INSERT INTO " + table
+		if len(columns) > 0 {
+			query = query + "(" + strings.Join(columns, ",") + ")"
+		}
+		query = query + output + " " + selectQuery
+	} else {
+		if len(s.columns) > 0 {
+			return "", nil, errors.New(ErrColumnsAndValues)
+		}
+		columns, values, err = rowValues(s.rows)
+		if err != nil {
+			return "", nil, err
+		}
+		columns, err = q.columns(columns)
+		if err != nil {
+			return "", nil, err
+		}
+
+		valuesSlice := make([]string, len(values))
+		for i, row := range values {
+			sqls, rowArgs, err := rowValueSql(s.dialect, row)
+			if err != nil {
+				return "", nil, err
+			}
+			valuesSlice[i] = "(" + strings.Join(sqls, ",") + ")"
+			args = append(args, rowArgs...)
+		}
+
+		//
+		// add table name
+		query = "INSERT INTO " + table + "(" + strings.Join(columns, ",") + ")" + output + " VALUES" + strings.Join(valuesSlice, ",")
+	}
 
-	for i := 0; i < len(s.indexedColumnValues); i++ {
-		indexedColumnValue := s.indexedColumnValues[i]
-		columns[i] = indexedColumnValue.Key
-		args[i] = indexedColumnValue.Value
+	//
+	// add conflict action
+	if s.conflict != nil {
+		query, args, err = s.buildUpsert(orDefault(s.dialect), query, args, columns, values, output)
+		if err != nil {
+			return "", nil, err
+		}
 	}
 
 	//
-	// add table name
-	query = "INSERT INTO " + s.table + "(" + strings.Join(columns, ",") + ") VALUES(" + strings.TrimSuffix(strings.Repeat("?,", len(columns)), ",") + ")"
+	// add returning columns
+	query = query + returning
+
+	//
+	// add common table expressions, in front of the select by selectSource if the dialect has no WITH in front of INSERT
+	if len(s.ctes) > 0 && orDefault(s.dialect).Supports(FeatureWithInsert) {
+		with, withArgs, err := buildWith(orDefault(s.dialect), s.ctes)
+		if err != nil {
+			return "", nil, err
+		}
+		query = with + query
+		args = append(withArgs, args...)
+	} else if len(s.ctes) > 0 && s.fromSelect == nil {
+		return "", nil, errors.New(ErrWithNotSupported)
+	}
+
+	// compare the number of args and ? in tableName
+	if len(args) != countPlaceholders(s.dialect, query) {
+		return "", nil, errors.New(ErrWrongNumberOfArgs)
+	}
 
 	return query, args, nil
 }
+
+// selectSource renders the select of INSERT ... SELECT.
+// If the dialect has no WITH in front of INSERT, the common table expressions are put in front of the select, like INSERT INTO t WITH c AS (...) SELECT ...
+func (s *InsertQuery) selectSource(d Dialect) (string, []interface{}, error) {
+	fromSelect := s.fromSelect
+	if s.conflict != nil && d.Driver() == DriverSqlite3 {
+		fromSelect = conflictSafeSelect(fromSelect)
+	}
+	query, args, err := fromSelect.ToSql()
+	if err != nil || len(s.ctes) == 0 || d.Supports(FeatureWithInsert) {
+		return query, args, err
+	}
+	with, withArgs, err := buildWith(d, s.ctes)
+	if err != nil {
+		return "", nil, err
+	}
+	return with + query, append(withArgs, args...), nil
+}
+
+// rowValues returns the columns of the first row and the values of each row in the order of the columns.
+// It fails if the rows don't have the same columns.
+func rowValues(rows []IndexedColumnValues) ([]string, [][]interface{}, error) {
+	if len(rows) == 0 || len(rows[0]) == 0 {
+		return nil, nil, errors.New(ErrColumnValueMapIsEmpty)
+	}
+
+	// make column slice
+	columns := make([]string, len(rows[0]))
+	columnIndexes := make(map[string]int, len(rows[0]))
+	for i := 0; i < len(rows[0]); i++ {
+		columns[i] = rows[0][i].Key
+		columnIndexes[columns[i]] = i
+	}
+
+	values := make([][]interface{}, len(rows))
+	for rowIndex, row := range rows {
+		values[rowIndex] = make([]interface{}, len(columns))
+		matched := 0
+		for i := 0; i < len(row); i++ {
+			columnIndex, ok := columnIndexes[row[i].Key]
+			if !ok {
+				break
+			}
+			values[rowIndex][columnIndex] = row[i].Value
+			matched++
+		}
+		if matched != len(columns) || len(row) != len(columns) {
+			rowColumns := make([]string, len(row))
+			for i := 0; i < len(row); i++ {
+				rowColumns[i] = row[i].Key
+			}
+			return nil, nil, fmt.Errorf("%s: row %d has columns (%s), expected (%s)", ErrRowColumnsMismatch, rowIndex, strings.Join(rowColumns, ","), strings.Join(columns, ","))
+		}
+	}
+	return columns, values, nil
+}
@@ -208,3 +208,417 @@ func TestInsertQuery_Rebind(t *testing.T) {
 		})
 	}
 }
+
+func TestInsertQuery_BuildDialect(t *testing.T) {
+	columnValues := map[string]interface{}{"field1": 10, "field2": "test"}
+
+	query, args, err := New(DialectPostgres).Insert("table1").MapValues(columnValues).Build()
+	require.NoError(t, err)
+	require.Equal(t, "INSERT INTO table1(field1,field2) VALUES($1,$2)", query)
+	require.Equal(t, []interface{}{10, "test"}, args)
+
+	query, _, err = New(DialectSqlServer).Insert("table1").MapValues(columnValues).Build()
+	require.NoError(t, err)
+	require.Equal(t, "INSERT INTO table1(field1,field2) VALUES(@p1,@p2)", query)
+
+	query, _, err = New(DialectMySQL).Insert("table1").MapValues(columnValues).Build()
+	require.NoError(t, err)
+	require.Equal(t, "INSERT INTO table1(field1,field2) VALUES(?,?)", query)
+}
+
+func TestInsertQuery_BuildRows(t *testing.T) {
+	type SampleStructType struct {
+		Name  string `db:"name"`
+		Grade int    `db:"grade"`
+	}
+
+	tests := []struct {
+		name      string
+		query     *InsertQuery
+		wantQuery string
+		wantArgs  []interface{}
+		wantErr   error
+	}{
+		{
+			name: "Rows",
+			query: New(DialectPostgres).Insert("table1").Rows([]map[string]interface{}{
+				{"field1": 1, "field2": "a"},
+				{"field2": "b", "field1": 2},
+			}),
+			wantQuery: "INSERT INTO table1(field1,field2) VALUES($1,$2),($3,$4)",
+			wantArgs:  []interface{}{1, "a", 2, "b"},
+		},
+		{
+			name: "StructsValues",
+			query: Insert("table1").StructsValues([]SampleStructType{
+				{Name: "Omid", Grade: 1},
+				{Name: "Jan", Grade: 2},
+				{Name: "Piet", Grade: 3},
+			}),
+			wantQuery: "INSERT INTO table1(name,grade) VALUES(?,?),(?,?),(?,?)",
+			wantArgs:  []interface{}{"Omid", 1, "Jan", 2, "Piet", 3},
+		},
+		{
+			name:      "StructsValues with pointers",
+			query:     Insert("table1").StructsValues([]*SampleStructType{{Name: "Omid", Grade: 1}}),
+			wantQuery: "INSERT INTO table1(name,grade) VALUES(?,?)",
+			wantArgs:  []interface{}{"Omid", 1},
+		},
+		{
+			name: "AddRow",
+			query: Insert("table1").
+				MapValues(map[string]interface{}{"field1": 1, "field2": "a"}).
+				AddRow(map[string]interface{}{"field1": 2, "field2": "b"}),
+			wantQuery: "INSERT INTO table1(field1,field2) VALUES(?,?),(?,?)",
+			wantArgs:  []interface{}{1, "a", 2, "b"},
+		},
+		{
+			name:      "AddRow only",
+			query:     Insert("table1").AddRow(map[string]interface{}{"field1": 1}),
+			wantQuery: "INSERT INTO table1(field1) VALUES(?)",
+			wantArgs:  []interface{}{1},
+		},
+		{
+			name: "missing column",
+			query: Insert("table1").Rows([]map[string]interface{}{
+				{"field1": 1, "field2": "a"},
+				{"field1": 2},
+			}),
+			wantErr: errors.New("all rows must have the same columns: row 1 has columns (field1), expected (field1,field2)"),
+		},
+		{
+			name: "different column",
+			query: Insert("table1").Rows([]map[string]interface{}{
+				{"field1": 1, "field2": "a"},
+				{"field1": 2, "field2": "b"},
+				{"field1": 3, "field3": "c"},
+			}),
+			wantErr: errors.New("all rows must have the same columns: row 2 has columns (field1,field3), expected (field1,field2)"),
+		},
+		{
+			name:    "no rows",
+			query:   Insert("table1").Rows(nil),
+			wantErr: errors.New(ErrColumnValueMapIsEmpty),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotQuery, gotArgs, err := tt.query.Build()
+			require.Equal(t, tt.wantErr, err)
+			require.Equal(t, tt.wantQuery, gotQuery)
+			require.Equal(t, tt.wantArgs, gotArgs)
+		})
+	}
+}
+
+func TestInsertQuery_AddRowDoesNotModifyQuery(t *testing.T) {
+	base := Insert("table1").MapValues(map[string]interface{}{"field1": 1})
+	first := base.AddRow(map[string]interface{}{"field1": 2})
+	second := base.AddRow(map[string]interface{}{"field1": 3})
+
+	_, args, err := first.Build()
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{1, 2}, args)
+	_, args, err = second.Build()
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{1, 3}, args)
+}
+
+func TestInsertPanicNotSlice(t *testing.T) {
+	require.Panics(t, func() {
+		Insert("table1").StructsValues(123)
+	}, "should panic with non slice types")
+	require.Panics(t, func() {
+		Insert("table1").StructsValues([]int{1})
+	}, "should panic with non struct elements")
+}
+
+func TestInsertQuery_BuildBatches(t *testing.T) {
+	rows := make([]map[string]interface{}, 5)
+	for i := range rows {
+		rows[i] = map[string]interface{}{"field1": i, "field2": "value"}
+	}
+
+	tests := []struct {
+		name      string
+		query     *InsertQuery
+		maxParams int
+		wantQuery []string
+		wantArgs  [][]interface{}
+		wantErr   bool
+	}{
+		{
+			name:      "split",
+			query:     Insert("table1").Rows(rows),
+			maxParams: 5,
+			wantQuery: []string{
+				"INSERT INTO table1(field1,field2) VALUES(?,?),(?,?)",
+				"INSERT INTO table1(field1,field2) VALUES(?,?),(?,?)",
+				"INSERT INTO table1(field1,field2) VALUES(?,?)",
+			},
+			wantArgs: [][]interface{}{
+				{0, "value", 1, "value"},
+				{2, "value", 3, "value"},
+				{4, "value"},
+			},
+		},
+		{
+			name:      "no limit",
+			query:     Insert("table1").Rows(rows[:2]),
+			wantQuery: []string{"INSERT INTO table1(field1,field2) VALUES(?,?),(?,?)"},
+			wantArgs:  [][]interface{}{{0, "value", 1, "value"}},
+		},
+		{
+			name:      "dialect limit",
+			query:     New(DialectPostgres).Insert("table1").Rows(rows[:2]),
+			wantQuery: []string{"INSERT INTO table1(field1,field2) VALUES($1,$2),($3,$4)"},
+			wantArgs:  [][]interface{}{{0, "value", 1, "value"}},
+		},
+		{
+			name:      "with args repeated",
+			query:     Insert("table1").With("t", "SELECT ?", 9).Rows(rows[:3]),
+			maxParams: 5,
+			wantQuery: []string{
+				"WITH t AS (SELECT ?) INSERT INTO table1(field1,field2) VALUES(?,?),(?,?)",
+				"WITH t AS (SELECT ?) INSERT INTO table1(field1,field2) VALUES(?,?)",
+			},
+			wantArgs: [][]interface{}{
+				{9, 0, "value", 1, "value"},
+				{9, 2, "value"},
+			},
+		},
+		{
+			name:      "row too large",
+			query:     Insert("table1").Rows(rows),
+			maxParams: 1,
+			wantErr:   true,
+		},
+		{
+			name:    "empty",
+			query:   Insert("table1"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			batches, err := tt.query.BuildBatches(tt.maxParams)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, batches, len(tt.wantQuery))
+			for i, batch := range batches {
+				require.Equal(t, tt.wantQuery[i], batch.Query)
+				require.Equal(t, tt.wantArgs[i], batch.Args)
+			}
+		})
+	}
+}
+
+func TestInsertQuery_BuildReturning(t *testing.T) {
+	columnValues := map[string]interface{}{"field1": "value1"}
+
+	query, args, err := New(DialectPostgres).Insert("table1").MapValues(columnValues).Returning("id", "created_at").Build()
+	require.NoError(t, err)
+	require.Equal(t, "INSERT INTO table1(field1) VALUES($1) RETURNING id,created_at", query)
+	require.Equal(t, []interface{}{"value1"}, args)
+
+	query, _, err = New(DialectSqlite3).Insert("table1").MapValues(columnValues).OnConflict("field1").DoNothing().Returning("id").Build()
+	require.NoError(t, err)
+	require.Equal(t, "INSERT INTO table1(field1) VALUES(?) ON CONFLICT (field1) DO NOTHING RETURNING id", query)
+
+	query, _, err = New(DialectSqlServer).Insert("table1").MapValues(columnValues).Returning("id").Build()
+	require.NoError(t, err)
+	require.Equal(t, "INSERT INTO table1(field1) OUTPUT INSERTED.id VALUES(@p1)", query)
+
+	query, _, err = New(DialectSqlServer).Insert("table1").MapValues(columnValues).OnConflict("field1").DoNothing().Returning("*").Build()
+	require.NoError(t, err)
+	require.Equal(t, "MERGE INTO table1 AS target USING (VALUES(@p1)) AS source(field1) ON (target.field1=source.field1) "+
+		"WHEN NOT MATCHED THEN INSERT(field1) VALUES(source.field1) OUTPUT INSERTED.*;", query)
+
+	_, _, err = New(DialectMySQL).Insert("table1").MapValues(columnValues).Returning("id").Build()
+	require.EqualError(t, err, ErrReturningNotSupported)
+}
+
+func TestInsertQuery_BuildFromSelect(t *testing.T) {
+	selectQuery := Select("table1").Columns("a,b").Where("created_at<?", "2020-01-01")
+
+	tests := []struct {
+		name      string
+		query     *InsertQuery
+		wantQuery string
+		wantArgs  []interface{}
+		wantErr   bool
+	}{
+		{
+			name:      "columns",
+			query:     Insert("archive").Columns("a", "b").FromSelect(selectQuery),
+			wantQuery: "INSERT INTO archive(a,b) SELECT a,b FROM table1 WHERE (created_at<?)",
+			wantArgs:  []interface{}{"2020-01-01"},
+		},
+		{
+			name:      "without columns",
+			query:     New(DialectPostgres).Insert("archive").FromSelect(selectQuery),
+			wantQuery: "INSERT INTO archive SELECT a,b FROM table1 WHERE (created_at<$1)",
+			wantArgs:  []interface{}{"2020-01-01"},
+		},
+		{
+			name:      "with",
+			query:     Insert("archive").With("t", "SELECT ?", 1).Columns("a", "b").FromSelect(selectQuery),
+			wantQuery: "WITH t AS (SELECT ?) INSERT INTO archive(a,b) SELECT a,b FROM table1 WHERE (created_at<?)",
+			wantArgs:  []interface{}{1, "2020-01-01"},
+		},
+		{
+			name:      "on conflict",
+			query:     Insert("archive").Columns("a", "b").FromSelect(selectQuery).OnConflict("a").DoNothing(),
+			wantQuery: "INSERT INTO archive(a,b) SELECT a,b FROM table1 WHERE (created_at<?) ON CONFLICT (a) DO NOTHING",
+			wantArgs:  []interface{}{"2020-01-01"},
+		},
+		{
+			name:      "sql server output",
+			query:     New(DialectSqlServer).Insert("archive").Columns("a", "b").FromSelect(selectQuery).Returning("a"),
+			wantQuery: "INSERT INTO archive(a,b) OUTPUT INSERTED.a SELECT a,b FROM table1 WHERE (created_at<@p1)",
+			wantArgs:  []interface{}{"2020-01-01"},
+		},
+		{
+			name:  "sql server merge",
+			query: New(DialectSqlServer).Insert("archive").Columns("a", "b").FromSelect(selectQuery).OnConflict("a").DoNothing(),
+			wantQuery: "MERGE INTO archive AS target USING (SELECT a,b FROM table1 WHERE (created_at<@p1)) AS source ON (target.a=source.a) " +
+				"WHEN NOT MATCHED THEN INSERT(a,b) VALUES(source.a,source.b);",
+			wantArgs: []interface{}{"2020-01-01"},
+		},
+		{
+			name:    "merge without columns",
+			query:   New(DialectSqlServer).Insert("archive").FromSelect(selectQuery).OnConflict("a").DoNothing(),
+			wantErr: true,
+		},
+		{
+			name:    "values and select",
+			query:   Insert("archive").MapValues(map[string]interface{}{"a": 1}).FromSelect(selectQuery),
+			wantErr: true,
+		},
+		{
+			name:    "columns and values",
+			query:   Insert("archive").Columns("a").MapValues(map[string]interface{}{"b": 1}),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotQuery, gotArgs, err := tt.query.Build()
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.wantQuery, gotQuery)
+			require.Equal(t, tt.wantArgs, gotArgs)
+		})
+	}
+}
+
+func TestInsertQuery_BuildExprValues(t *testing.T) {
+	query, args, err := New(DialectPostgres).Insert("table1").Rows([]map[string]interface{}{
+		{"name": "value1", "created_at": Expr("NOW()")},
+		{"name": "value2", "created_at": Expr("NOW() - ?::interval", "1 day")},
+	}).Build()
+	require.NoError(t, err)
+	require.Equal(t, "INSERT INTO table1(created_at,name) VALUES(NOW(),$1),(NOW() - $2::interval,$3)", query)
+	require.Equal(t, []interface{}{"value1", "1 day", "value2"}, args)
+
+	query, args, err = Insert("table1").MapValues(map[string]interface{}{"counter": 1}).
+		OnConflict("id").DoUpdateSet(map[string]interface{}{"counter": Increment(1)}).Build()
+	require.NoError(t, err)
+	require.Equal(t, "INSERT INTO table1(counter) VALUES(?) ON CONFLICT (id) DO UPDATE SET counter=table1.counter+?", query)
+	require.Equal(t, []interface{}{1, 1}, args)
+
+	query, _, err = New(DialectPostgres).Insert("table1 AS t").MapValues(map[string]interface{}{"counter": 1}).
+		OnConflict("id").DoUpdateSet(map[string]interface{}{"counter": Decrement(1)}).Build()
+	require.NoError(t, err)
+	require.Equal(t, "INSERT INTO table1 AS t(counter) VALUES($1) ON CONFLICT (id) DO UPDATE SET counter=t.counter-$2", query)
+
+	query, args, err = New(DialectSqlServer).Insert("table1").MapValues(map[string]interface{}{"id": 1, "n": 1}).
+		OnConflict("id").DoUpdateSet(map[string]interface{}{"n": Increment(1)}).Build()
+	require.NoError(t, err)
+	require.Equal(t, "MERGE INTO table1 AS target USING (VALUES(@p1,@p2)) AS source(id,n) ON (target.id=source.id) WHEN MATCHED THEN UPDATE SET n=target.n+@p3 WHEN NOT MATCHED THEN INSERT(id,n) VALUES(source.id,source.n);", query)
+	require.Equal(t, []interface{}{1, 1, 1}, args)
+
+	query, _, err = New(DialectOCI8).Insert("table1").MapValues(map[string]interface{}{"id": 1, "n": 1}).
+		OnConflict("id").DoUpdateSet(map[string]interface{}{"n": Increment(1)}).Build()
+	require.NoError(t, err)
+	require.Equal(t, "MERGE INTO table1 target USING (SELECT :arg1 id,:arg2 n FROM dual) source ON (target.id=source.id) WHEN MATCHED THEN UPDATE SET n=target.n+:arg3 WHEN NOT MATCHED THEN INSERT(id,n) VALUES(source.id,source.n)", query)
+
+	_, _, err = Insert("table1").MapValues(map[string]interface{}{"counter": Increment(1)}).Build()
+	require.EqualError(t, err, ErrAdjustmentNotSet)
+
+	_, _, err = New(DialectPostgres).Insert("table1").MapValues(map[string]interface{}{"a": Expr("?", 1, 2)}).Build()
+	require.EqualError(t, err, ErrWrongNumberOfArgs)
+
+	_, _, err = Insert("table1").MapValues(map[string]interface{}{"counter": 1}).
+		OnConflict("id").DoUpdateSet(map[string]interface{}{"counter": Expr("counter+?")}).Build()
+	require.EqualError(t, err, ErrWrongNumberOfArgs)
+
+	batches, err := Insert("table1").Rows([]map[string]interface{}{
+		{"name": Expr("?||?", "a", "b")},
+		{"name": Expr("?||?", "c", "d")},
+		{"name": "e"},
+	}).BuildBatches(3)
+	require.NoError(t, err)
+	require.Len(t, batches, 2)
+	require.Equal(t, "INSERT INTO table1(name) VALUES(?||?)", batches[0].Query)
+	require.Equal(t, "INSERT INTO table1(name) VALUES(?||?),(?)", batches[1].Query)
+	require.Equal(t, []interface{}{"c", "d", "e"}, batches[1].Args)
+}
+
+type taggedAudit struct {
+	CreatedAt time.Time `db:"created_at,readonly"`
+	CreatedBy string    `db:"created_by,-update"`
+	UpdatedBy string    `db:"updated_by,-insert"`
+}
+
+type taggedUser struct {
+	ID     int64   `db:"id,pk,default"`
+	Name   string  `db:"name"`
+	Email  *string `db:"email,omitempty"`
+	Status string  `db:"status,default"`
+	Level  int     `db:"level,omitempty"`
+	Audit  taggedAudit
+}
+
+func TestInsertQuery_BuildTagOptions(t *testing.T) {
+	email := "a@example.com"
+	tests := []struct {
+		name      string
+		query     *InsertQuery
+		wantQuery string
+		wantArgs  []interface{}
+	}{
+		{
+			name:      "zero values",
+			query:     Insert("users").StructValues(taggedUser{Name: "a", Audit: taggedAudit{CreatedBy: "b", UpdatedBy: "c"}}),
+			wantQuery: "INSERT INTO users(name,created_by) VALUES(?,?)",
+			wantArgs:  []interface{}{"a", "b"},
+		},
+		{
+			name:      "set values",
+			query:     Insert("users").StructValues(&taggedUser{ID: 1, Name: "a", Email: &email, Status: "active", Level: 2, Audit: taggedAudit{CreatedAt: time.Now()}}),
+			wantQuery: "INSERT INTO users(id,name,email,status,level,created_by) VALUES(?,?,?,?,?,?)",
+			wantArgs:  []interface{}{int64(1), "a", email, "active", 2, ""},
+		},
+		{
+			name:      "rows",
+			query:     Insert("users").StructsValues([]taggedUser{{Name: "a"}, {Name: "b"}}),
+			wantQuery: "INSERT INTO users(name,created_by) VALUES(?,?),(?,?)",
+			wantArgs:  []interface{}{"a", "", "b", ""},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, args, err := tt.query.Build()
+			require.NoError(t, err)
+			require.Equal(t, tt.wantQuery, query)
+			require.Equal(t, tt.wantArgs, args)
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package querybuilder
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+// Cursor holds the values of the ORDER BY columns of the last row of a page, in the order of the columns.
+// It is used by After and Before for keyset pagination.
+type Cursor []interface{}
+
+// Encode returns an opaque token of the cursor which can be passed to clients, see DecodeCursor
+func (c Cursor) Encode() (string, error) {
+	data, err := json.Marshal([]interface{}(c))
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// DecodeCursor decodes a token created by Cursor.Encode.
+// Integer numbers are decoded as int64 and other numbers as float64, times are decoded as strings.
+func DecodeCursor(token string) (Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, errors.New(ErrInvalidCursor)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var values []interface{}
+	if err := decoder.Decode(&values); err != nil {
+		return nil, errors.New(ErrInvalidCursor)
+	}
+	for i, value := range values {
+		number, ok := value.(json.Number)
+		if !ok {
+			continue
+		}
+		if n, err := number.Int64(); err == nil {
+			values[i] = n
+		} else if f, err := number.Float64(); err == nil {
+			values[i] = f
+		} else {
+			return nil, errors.New(ErrInvalidCursor)
+		}
+	}
+	return values, nil
+}
+
+type keysetClause struct {
+	cursor Cursor
+	before bool
+}
+
+// keysetCondition selects the rows after the cursor in the order of orderBy.
+// A row value comparison like (a,b)>(?,?) is used if all columns have the same direction and the dialect supports it,
+// otherwise the comparison is expanded to (a>?) OR (a=? AND b>?).
+func keysetCondition(d Dialect, orderBy []orderByClause, cursor Cursor) (Sqlizer, error) {
+	if len(cursor) == 0 || len(cursor) != len(orderBy) {
+		return nil, errors.New(ErrCursorMismatch)
+	}
+	operators := make([]string, len(orderBy))
+	columns := make([]string, len(orderBy))
+	sameDirection := true
+	for i, clause := range orderBy {
+		columns[i] = clause.field
+		operators[i] = ">"
+		if clause.direction == OrderDesc {
+			operators[i] = "<"
+		}
+		if clause.direction != orderBy[0].direction {
+			sameDirection = false
+		}
+	}
+
+	if len(columns) == 1 {
+		return expr{sql: columns[0] + operators[0] + "?", args: []interface{}{cursor[0]}}, nil
+	}
+	if sameDirection && d.Supports(FeatureRowValues) {
+		placeholders := strings.TrimSuffix(strings.Repeat("?,", len(cursor)), ",")
+		return expr{sql: "(" + strings.Join(columns, ",") + ")" + operators[0] + "(" + placeholders + ")", args: cursor}, nil
+	}
+
+	alternatives := make([]string, len(columns))
+	var args []interface{}
+	for i := range columns {
+		var comparisons []string
+		for j := 0; j < i; j++ {
+			comparisons = append(comparisons, columns[j]+"=?")
+			args = append(args, cursor[j])
+		}
+		comparisons = append(comparisons, columns[i]+operators[i]+"?")
+		args = append(args, cursor[i])
+		alternatives[i] = "(" + strings.Join(comparisons, " AND ") + ")"
+	}
+	return expr{sql: strings.Join(alternatives, " OR "), args: args}, nil
+}
+
+// reverseOrder returns orderBy with every direction flipped
+func reverseOrder(orderBy []orderByClause) []orderByClause {
+	reversed := make([]orderByClause, len(orderBy))
+	for i, clause := range orderBy {
+		reversed[i] = clause
+		if clause.direction == OrderDesc {
+			reversed[i].direction = OrderAsc
+		} else {
+			reversed[i].direction = OrderDesc
+		}
+	}
+	return reversed
+}
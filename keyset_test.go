@@ -0,0 +1,96 @@
+package querybuilder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelectQuery_BuildKeyset(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     *SelectQuery
+		wantQuery string
+		wantArgs  []interface{}
+		wantErr   bool
+	}{
+		{
+			name:      "single column",
+			query:     Select("table1").Order("id", OrderAsc).After(Cursor{10}).Limit(20),
+			wantQuery: "SELECT * FROM table1 WHERE (id>?) ORDER BY id ASC LIMIT 20",
+			wantArgs:  []interface{}{10},
+		},
+		{
+			name:      "row values",
+			query:     New(DialectPostgres).Select("table1").Where("status=?", "active").Order("created_at", OrderDesc).Order("id", OrderDesc).After(Cursor{"2020-01-01", 10}),
+			wantQuery: "SELECT * FROM table1 WHERE (status=$1) AND ((created_at,id)<($2,$3)) ORDER BY created_at DESC,id DESC",
+			wantArgs:  []interface{}{"active", "2020-01-01", 10},
+		},
+		{
+			name:      "mixed directions",
+			query:     Select("table1").Order("name", OrderAsc).Order("id", OrderDesc).After(Cursor{"b", 10}),
+			wantQuery: "SELECT * FROM table1 WHERE ((name>?) OR (name=? AND id<?)) ORDER BY name ASC,id DESC",
+			wantArgs:  []interface{}{"b", "b", 10},
+		},
+		{
+			name:      "without row values",
+			query:     New(DialectSqlServer).Select("table1").Order("a", OrderAsc).Order("b", OrderAsc).Order("c", OrderAsc).After(Cursor{1, 2, 3}),
+			wantQuery: "SELECT * FROM table1 WHERE ((a>@p1) OR (a=@p2 AND b>@p3) OR (a=@p4 AND b=@p5 AND c>@p6)) ORDER BY a ASC,b ASC,c ASC",
+			wantArgs:  []interface{}{1, 1, 2, 1, 2, 3},
+		},
+		{
+			name:      "before",
+			query:     Select("table1").Order("id", OrderAsc).Before(Cursor{10}).Limit(20),
+			wantQuery: "SELECT * FROM table1 WHERE (id<?) ORDER BY id DESC LIMIT 20",
+			wantArgs:  []interface{}{10},
+		},
+		{
+			name:      "or conditions are grouped",
+			query:     Select("table1").Where("a=?", 1).OrWhere("b=?", 2).Order("id", OrderAsc).After(Cursor{10}),
+			wantQuery: "SELECT * FROM table1 WHERE ((a=?) OR (b=?)) AND (id>?) ORDER BY id ASC",
+			wantArgs:  []interface{}{1, 2, 10},
+		},
+		{
+			name:      "compound in derived table",
+			query:     SelectFrom(Select("t").Union(Select("u")), "x").Order("id", OrderAsc).After(Cursor{5}),
+			wantQuery: "SELECT * FROM (SELECT * FROM t UNION SELECT * FROM u) x WHERE (id>?) ORDER BY id ASC",
+			wantArgs:  []interface{}{5},
+		},
+		{
+			name:    "compound",
+			query:   Select("t").Union(Select("u")).Order("id", OrderAsc).After(Cursor{5}),
+			wantErr: true,
+		},
+		{
+			name:    "cursor mismatch",
+			query:   Select("table1").Order("id", OrderAsc).After(Cursor{10, 20}),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotQuery, gotArgs, err := tt.query.Build()
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.wantQuery, gotQuery)
+			require.Equal(t, tt.wantArgs, gotArgs)
+		})
+	}
+	_, _, err := Select("t").Except(Select("u")).Order("id", OrderAsc).Before(Cursor{5}).Build()
+	require.EqualError(t, err, ErrKeysetWithCompound)
+}
+
+func TestCursor_Encode(t *testing.T) {
+	token, err := Cursor{"2020-01-01", 10, 1.5}.Encode()
+	require.NoError(t, err)
+
+	cursor, err := DecodeCursor(token)
+	require.NoError(t, err)
+	require.Equal(t, Cursor{"2020-01-01", int64(10), 1.5}, cursor)
+
+	_, err = DecodeCursor("not a cursor")
+	require.EqualError(t, err, ErrInvalidCursor)
+}
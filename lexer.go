@@ -0,0 +1,152 @@
+package querybuilder
+
+import "strings"
+
+type tokenKind int
+
+const (
+	// tokenText is SQL text, including string literals, quoted identifiers and comments
+	tokenText tokenKind = iota
+	// tokenPlaceholder is a ? bind placeholder
+	tokenPlaceholder
+	// tokenQuestionMark is an escaped literal question mark written as ??
+	tokenQuestionMark
+)
+
+// scanQuery splits query into text, placeholders and escaped question marks and calls fn for each of them.
+// Question marks inside quoted strings, quoted identifiers, dollar-quoted strings and comments are not placeholders.
+// A literal question mark outside of them, e.g. the PostgreSQL JSONB operators ?, ?| and ?&, is written as ??.
+// Backslash escapes in strings and # comments are recognized if d supports them, like MySQL. A nil d is DialectDefault.
+func scanQuery(d Dialect, query string, fn func(kind tokenKind, text string)) {
+	d = orDefault(d)
+	backslash := d.Supports(FeatureBackslashEscapes)
+	hashComments := d.Supports(FeatureHashComments)
+	start := 0
+	for i := 0; i < len(query); {
+		switch c := query[i]; {
+		case c == '?':
+			if start < i {
+				fn(tokenText, query[start:i])
+			}
+			if i+1 < len(query) && query[i+1] == '?' {
+				fn(tokenQuestionMark, query[i:i+2])
+				i += 2
+			} else {
+				fn(tokenPlaceholder, query[i:i+1])
+				i++
+			}
+			start = i
+		case c == '\'':
+			i = skipQuoted(query, i, '\'', backslash || isEscapeString(query, i))
+		case c == '"':
+			i = skipQuoted(query, i, c, backslash)
+		case c == '`':
+			i = skipQuoted(query, i, c, false)
+		case c == '-' && strings.HasPrefix(query[i:], "--"), c == '#' && hashComments:
+			i = skipLineComment(query, i)
+		case c == '/' && strings.HasPrefix(query[i:], "/*"):
+			i = skipBlockComment(query, i)
+		case c == '$' && (i == 0 || !isIdentifierChar(query[i-1])):
+			i = skipDollarQuoted(query, i)
+		default:
+			i++
+		}
+	}
+	if start < len(query) {
+		fn(tokenText, query[start:])
+	}
+}
+
+// countPlaceholders returns the number of bind placeholders in query, written in the SQL of d
+func countPlaceholders(d Dialect, query string) int {
+	count := 0
+	scanQuery(d, query, func(kind tokenKind, text string) {
+		if kind == tokenPlaceholder {
+			count++
+		}
+	})
+	return count
+}
+
+// skipQuoted returns the position after the quoted section starting at i.
+// A doubled quote character is an escaped quote, backslash escapes are honored only if backslash is true.
+func skipQuoted(query string, i int, quote byte, backslash bool) int {
+	for j := i + 1; j < len(query); j++ {
+		switch query[j] {
+		case '\\':
+			if backslash {
+				j++
+			}
+		case quote:
+			if j+1 < len(query) && query[j+1] == quote {
+				j++
+				continue
+			}
+			return j + 1
+		}
+	}
+	return len(query)
+}
+
+// isEscapeString reports whether the string literal starting at i is a PostgreSQL escape string like E'\n'
+func isEscapeString(query string, i int) bool {
+	if i == 0 || (query[i-1] != 'E' && query[i-1] != 'e') {
+		return false
+	}
+	return i == 1 || !isIdentifierChar(query[i-2])
+}
+
+// skipLineComment returns the position after the comment starting at i, which ends at the end of the line
+func skipLineComment(query string, i int) int {
+	if end := strings.IndexByte(query[i:], '\n'); end != -1 {
+		return i + end + 1
+	}
+	return len(query)
+}
+
+// skipBlockComment returns the position after the (possibly nested) comment starting at i
+func skipBlockComment(query string, i int) int {
+	depth := 0
+	for i < len(query) {
+		switch {
+		case strings.HasPrefix(query[i:], "/*"):
+			depth++
+			i += 2
+		case strings.HasPrefix(query[i:], "*/"):
+			depth--
+			i += 2
+			if depth == 0 {
+				return i
+			}
+		default:
+			i++
+		}
+	}
+	return i
+}
+
+// skipDollarQuoted returns the position after the dollar-quoted string like $tag$...$tag$ starting at i.
+// If there is no dollar quote at i, e.g. a $1 placeholder, the position after the $ is returned.
+func skipDollarQuoted(query string, i int) int {
+	j := i + 1
+	for j < len(query) && query[j] != '$' && isIdentifierChar(query[j]) {
+		j++
+	}
+	if j == len(query) || query[j] != '$' || (j > i+1 && isDigit(query[i+1])) {
+		return i + 1
+	}
+	delimiter := query[i : j+1]
+	end := strings.Index(query[j+1:], delimiter)
+	if end == -1 {
+		return len(query)
+	}
+	return j + 1 + end + len(delimiter)
+}
+
+func isIdentifierChar(c byte) bool {
+	return c == '_' || c == '$' || isDigit(c) || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || c >= 0x80
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
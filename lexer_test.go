@@ -0,0 +1,46 @@
+package querybuilder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_countPlaceholders(t *testing.T) {
+	tests := []struct {
+		name    string
+		dialect Dialect
+		query   string
+		want    int
+	}{
+		{name: "no placeholders", query: "SELECT * FROM table1", want: 0},
+		{name: "placeholders", query: "SELECT * FROM table1 WHERE id=? AND name=?", want: 2},
+		{name: "string literal", query: "SELECT * FROM table1 WHERE note='what?' AND id=?", want: 1},
+		{name: "doubled quote in literal", query: "SELECT 'it''s ?' , ?", want: 1},
+		{name: "escape string", query: `SELECT E'\'?' , ?`, want: 1},
+		{name: "backslash in standard string", query: `SELECT 'C:\' , ?`, want: 1},
+		{name: "quoted identifier", query: `SELECT "what?" FROM table1 WHERE id=?`, want: 1},
+		{name: "backtick identifier", query: "SELECT `what?` FROM table1 WHERE id=?", want: 1},
+		{name: "line comment", query: "SELECT * FROM table1 -- why?\nWHERE id=?", want: 1},
+		{name: "line comment at the end", query: "SELECT * FROM table1 WHERE id=? -- why?", want: 1},
+		{name: "block comment", query: "SELECT /* why? */ * FROM table1 WHERE id=?", want: 1},
+		{name: "nested block comment", query: "SELECT /* a /* b? */ c? */ ?", want: 1},
+		{name: "dollar quoted", query: "SELECT $$what?$$, ?", want: 1},
+		{name: "tagged dollar quoted", query: "SELECT $fn$ a $$?$$ $fn$, ?", want: 1},
+		{name: "positional parameter", query: "SELECT $1, ?", want: 1},
+		{name: "dollar in identifier", query: "SELECT a$b, ?, c$d", want: 1},
+		{name: "escaped question marks", query: "SELECT * FROM table1 WHERE data ?? ? AND data ??| ?", want: 2},
+		{name: "unterminated literal", query: "SELECT ? WHERE a='?", want: 1},
+		{name: "hash is an operator", query: "SELECT a # ? FROM table1", want: 1},
+		{name: "mysql backslash in string", dialect: DialectMySQL, query: `SELECT * FROM t WHERE a='x\'?' AND b=? AND c=?`, want: 2},
+		{name: "mysql backslash in double quoted string", dialect: DialectMySQL, query: `SELECT "x\"?", ?`, want: 1},
+		{name: "mysql backslash in backtick identifier", dialect: DialectMySQL, query: "SELECT `a\\` , ?", want: 1},
+		{name: "mysql hash comment", dialect: DialectMySQL, query: "SELECT * FROM t # why?\nWHERE id=?", want: 1},
+		{name: "mysql hash comment at the end", dialect: DialectMySQL, query: "SELECT * FROM t WHERE id=? # why?", want: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, countPlaceholders(tt.dialect, tt.query))
+		})
+	}
+}
@@ -1,36 +1,140 @@
 package querybuilder
 
+// Driver is used by Rebind to choose the bindvar type.
+//
+// Deprecated: Driver is global state shared by the whole program. Create a Builder with New instead.
 var Driver DriverName
 
+// Builder creates queries which are rendered for a specific Dialect
+type Builder struct {
+	dialect        Dialect
+	bindPagination bool
+	quoteMode      QuoteMode
+	runner         Runner
+}
+
+var defaultBuilder = New(DialectDefault)
+
+// New creates a new Builder for the dialect
+func New(dialect Dialect) *Builder {
+	return &Builder{dialect: orDefault(dialect)}
+}
+
+// Dialect returns the dialect of the Builder
+func (b *Builder) Dialect() Dialect {
+	return b.dialect
+}
+
+// BindPagination returns a copy of the Builder whose select queries bind limit and offset as args instead of inlining them
+func (b *Builder) BindPagination() *Builder {
+	newBuilder := *b
+	newBuilder.bindPagination = true
+	return &newBuilder
+}
+
+// Quote returns a copy of the Builder whose queries quote table names and the columns of
+// MapValues, StructValues, Columns, OnConflict, DoUpdateSet and Returning with the quotes of the dialect.
+// Raw SQL like conditions and the columns of a select is never quoted.
+// Without it, the columns of MapValues, Rows and DoUpdateSet are written to the query as they are and are not validated,
+// use QuoteStrict when they come from user input.
+func (b *Builder) Quote(mode QuoteMode) *Builder {
+	newBuilder := *b
+	newBuilder.quoteMode = mode
+	return &newBuilder
+}
+
+// RunWith returns a copy of the Builder whose queries are executed by runner, like a *sql.DB, *sql.Tx or *sql.Conn
+func (b *Builder) RunWith(runner Runner) *Builder {
+	newBuilder := *b
+	newBuilder.runner = runner
+	return &newBuilder
+}
+
 // Select creates new SelectQuery
-func Select(name string) *SelectQuery {
+func (b *Builder) Select(name string) *SelectQuery {
 	sq := SelectQuery{}
+	sq.dialect = b.dialect
+	sq.bindPagination = b.bindPagination
+	sq.quoteMode = b.quoteMode
+	sq.runner = b.runner
 	sq.table = name
 	return &sq
 }
 
+// SelectFrom creates new SelectQuery which selects from the derived table sub with the alias
+func (b *Builder) SelectFrom(sub *SelectQuery, alias string) *SelectQuery {
+	sq := SelectQuery{}
+	sq.dialect = b.dialect
+	sq.bindPagination = b.bindPagination
+	sq.quoteMode = b.quoteMode
+	sq.runner = b.runner
+	if sub != nil {
+		sq.from = derivedTable{query: sub, alias: alias}
+	}
+	return &sq
+}
+
 // Insert creates new InsertQuery
-func Insert(name string) *InsertQuery {
+func (b *Builder) Insert(name string) *InsertQuery {
 	iq := InsertQuery{}
+	iq.dialect = b.dialect
+	iq.quoteMode = b.quoteMode
+	iq.runner = b.runner
 	iq.table = name
 	return &iq
 }
 
 // Update creates new UpdateQuery
-func Update(name string) *UpdateQuery {
+func (b *Builder) Update(name string) *UpdateQuery {
 	uq := UpdateQuery{}
+	uq.dialect = b.dialect
+	uq.quoteMode = b.quoteMode
+	uq.runner = b.runner
 	uq.table = name
 	return &uq
 }
 
 // Delete creates new DeleteQuery
-func Delete(name string) *DeleteQuery {
+func (b *Builder) Delete(name string) *DeleteQuery {
 	dq := DeleteQuery{}
+	dq.dialect = b.dialect
+	dq.quoteMode = b.quoteMode
+	dq.runner = b.runner
 	dq.table = name
 	return &dq
 }
 
+// Rebind transforms a query table QUESTION to the bindvar type of the Builder's dialect.
+func (b *Builder) Rebind(query string) string {
+	return rebind(orDefault(b.dialect), query)
+}
+
+// Select creates new SelectQuery
+func Select(name string) *SelectQuery {
+	return defaultBuilder.Select(name)
+}
+
+// SelectFrom creates new SelectQuery which selects from the derived table sub with the alias
+func SelectFrom(sub *SelectQuery, alias string) *SelectQuery {
+	return defaultBuilder.SelectFrom(sub, alias)
+}
+
+// Insert creates new InsertQuery
+func Insert(name string) *InsertQuery {
+	return defaultBuilder.Insert(name)
+}
+
+// Update creates new UpdateQuery
+func Update(name string) *UpdateQuery {
+	return defaultBuilder.Update(name)
+}
+
+// Delete creates new DeleteQuery
+func Delete(name string) *DeleteQuery {
+	return defaultBuilder.Delete(name)
+}
+
 // Rebind transforms a query table QUESTION to the DB driver's bindvar type.
 func Rebind(query string) string {
-	return rebind(BindType(Driver), query)
+	return rebind(DialectFor(Driver), query)
 }
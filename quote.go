@@ -0,0 +1,127 @@
+package querybuilder
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// QuoteMode tells how table and column names are quoted, see Builder.Quote
+type QuoteMode int
+
+const (
+	// QuoteNone leaves identifiers as they are
+	QuoteNone QuoteMode = iota
+	// QuoteAll quotes identifiers with the quotes of the dialect
+	QuoteAll
+	// QuoteStrict quotes identifiers and rejects the ones which are not made of letters, digits and underscores
+	QuoteStrict
+)
+
+var safeIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+type identifierQuoter struct {
+	dialect Dialect
+	mode    QuoteMode
+}
+
+// column quotes a column name or a qualified name like table.column, * is not quoted
+func (q identifierQuoter) column(name string) (string, error) {
+	if q.mode == QuoteNone {
+		return name, nil
+	}
+	parts := strings.Split(name, ".")
+	for i, part := range parts {
+		if part == "*" && i == len(parts)-1 {
+			continue
+		}
+		if q.mode == QuoteStrict && !safeIdentifier.MatchString(part) {
+			return "", fmt.Errorf("%s: %q", ErrInvalidIdentifier, name)
+		}
+		parts[i] = orDefault(q.dialect).QuoteIdentifier(part)
+	}
+	return strings.Join(parts, "."), nil
+}
+
+// columns quotes each column name
+func (q identifierQuoter) columns(names []string) ([]string, error) {
+	if q.mode == QuoteNone || len(names) == 0 {
+		return names, nil
+	}
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		var err error
+		quoted[i], err = q.column(name)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return quoted, nil
+}
+
+// table quotes a table name like schema.table, with an optional alias like "users u" or "users AS u"
+func (q identifierQuoter) table(name string) (string, error) {
+	if q.mode == QuoteNone {
+		return name, nil
+	}
+	fields := strings.Fields(name)
+	if len(fields) == 3 && strings.EqualFold(fields[1], "AS") {
+		fields = []string{fields[0], fields[2]}
+	}
+	switch len(fields) {
+	case 1:
+		return q.column(fields[0])
+	case 2:
+		table, err := q.column(fields[0])
+		if err != nil {
+			return "", err
+		}
+		alias, err := q.column(fields[1])
+		if err != nil {
+			return "", err
+		}
+		return table + " " + alias, nil
+	}
+	return "", fmt.Errorf("%s: %q", ErrInvalidIdentifier, name)
+}
+
+// conflict returns a copy of the conflict clause with quoted columns, including the columns of Excluded values
+func (q identifierQuoter) conflict(conflict *conflictClause) (*conflictClause, error) {
+	if q.mode == QuoteNone {
+		return conflict, nil
+	}
+	columns, err := q.columns(conflict.columns)
+	if err != nil {
+		return nil, err
+	}
+	set, err := q.columnValues(conflict.set)
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; i < len(set); i++ {
+		if e, ok := set[i].Value.(excluded); ok {
+			column, err := q.column(e.column)
+			if err != nil {
+				return nil, err
+			}
+			set[i] = KeyValue{Key: set[i].Key, Value: excluded{column: column}}
+		}
+	}
+	return &conflictClause{columns: columns, doNothing: conflict.doNothing, set: set}, nil
+}
+
+// columnValues returns a copy of the column/values with quoted columns
+func (q identifierQuoter) columnValues(columnValues IndexedColumnValues) (IndexedColumnValues, error) {
+	if q.mode == QuoteNone {
+		return columnValues, nil
+	}
+	quoted := make(IndexedColumnValues, len(columnValues))
+	for i := 0; i < len(columnValues); i++ {
+		column, err := q.column(columnValues[i].Key)
+		if err != nil {
+			return nil, err
+		}
+		quoted[i] = KeyValue{Key: column, Value: columnValues[i].Value}
+	}
+	return quoted, nil
+}
@@ -0,0 +1,81 @@
+package querybuilder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuilder_Quote(t *testing.T) {
+	columnValues := map[string]interface{}{"order": 1, "user": "value1"}
+
+	tests := []struct {
+		name      string
+		query     queryBuilder
+		wantQuery string
+		wantErr   bool
+	}{
+		{
+			name:      "insert postgres",
+			query:     New(DialectPostgres).Quote(QuoteAll).Insert("public.user").MapValues(columnValues).Returning("id"),
+			wantQuery: `INSERT INTO "public"."user"("order","user") VALUES($1,$2) RETURNING "id"`,
+		},
+		{
+			name: "insert on conflict",
+			query: New(DialectPostgres).Quote(QuoteAll).Insert("user").MapValues(columnValues).
+				OnConflict("order").DoUpdateSet(map[string]interface{}{"user": Excluded("user")}),
+			wantQuery: `INSERT INTO "user"("order","user") VALUES($1,$2) ON CONFLICT ("order") DO UPDATE SET "user"=EXCLUDED."user"`,
+		},
+		{
+			name:      "update mysql",
+			query:     New(DialectMySQL).Quote(QuoteAll).Update("user").MapValues(columnValues).Where("id=?", 1),
+			wantQuery: "UPDATE `user` SET `order`=?,`user`=? WHERE (id=?)",
+		},
+		{
+			name:      "update sql server with alias",
+			query:     New(DialectSqlServer).Quote(QuoteStrict).Update("user u").MapValues(columnValues).Joins("orders o", "o.user_id=u.id", JoinInner),
+			wantQuery: "UPDATE [u] SET [order]=@p1,[user]=@p2 FROM [user] [u] JOIN orders o ON o.user_id=u.id",
+		},
+		{
+			name:      "delete",
+			query:     New(DialectPostgres).Quote(QuoteAll).Delete("user AS u").Where("u.id=?", 1),
+			wantQuery: `DELETE FROM "user" "u" WHERE (u.id=$1)`,
+		},
+		{
+			name:      "select table",
+			query:     New(DialectPostgres).Quote(QuoteAll).Select("user").Columns("id"),
+			wantQuery: `SELECT id FROM "user"`,
+		},
+		{
+			name:      "quote characters are escaped",
+			query:     New(DialectPostgres).Quote(QuoteAll).Insert("user").MapValues(map[string]interface{}{`a"b`: 1}),
+			wantQuery: `INSERT INTO "user"("a""b") VALUES($1)`,
+		},
+		{
+			name:      "no quoting by default",
+			query:     New(DialectPostgres).Insert("user").MapValues(columnValues),
+			wantQuery: `INSERT INTO user(order,user) VALUES($1,$2)`,
+		},
+		{
+			name:    "strict rejects unsafe column",
+			query:   New(DialectPostgres).Quote(QuoteStrict).Insert("user").MapValues(map[string]interface{}{"a) VALUES(1); --": 1}),
+			wantErr: true,
+		},
+		{
+			name:    "strict rejects unsafe table",
+			query:   New(DialectPostgres).Quote(QuoteStrict).Update("user;").MapValues(columnValues),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotQuery, _, err := tt.query.Build()
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.wantQuery, gotQuery)
+		})
+	}
+}
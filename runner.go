@@ -0,0 +1,69 @@
+package querybuilder
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+)
+
+// Runner executes queries, it is implemented by *sql.DB, *sql.Tx and *sql.Conn
+type Runner interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// Row is the result of QueryRowContext, it also holds the error of building the query
+type Row struct {
+	row *sql.Row
+	err error
+}
+
+// Scan copies the columns of the row into dest like sql.Row.Scan, it returns the error of building the query if any
+func (r *Row) Scan(dest ...interface{}) error {
+	if r.err != nil {
+		return r.err
+	}
+	return r.row.Scan(dest...)
+}
+
+// Err returns the error of building or running the query
+func (r *Row) Err() error {
+	if r.err != nil {
+		return r.err
+	}
+	return r.row.Err()
+}
+
+// execContext builds the query with the dialect's placeholders and executes it with runner
+func execContext(ctx context.Context, runner Runner, query string, args []interface{}, err error) (sql.Result, error) {
+	if err != nil {
+		return nil, err
+	}
+	if runner == nil {
+		return nil, errors.New(ErrRunnerNotSet)
+	}
+	return runner.ExecContext(ctx, query, args...)
+}
+
+// queryContext builds the query with the dialect's placeholders and runs it with runner
+func queryContext(ctx context.Context, runner Runner, query string, args []interface{}, err error) (*sql.Rows, error) {
+	if err != nil {
+		return nil, err
+	}
+	if runner == nil {
+		return nil, errors.New(ErrRunnerNotSet)
+	}
+	return runner.QueryContext(ctx, query, args...)
+}
+
+// queryRowContext builds the query with the dialect's placeholders and runs it with runner
+func queryRowContext(ctx context.Context, runner Runner, query string, args []interface{}, err error) *Row {
+	if err != nil {
+		return &Row{err: err}
+	}
+	if runner == nil {
+		return &Row{err: errors.New(ErrRunnerNotSet)}
+	}
+	return &Row{row: runner.QueryRowContext(ctx, query, args...)}
+}
@@ -0,0 +1,205 @@
+package querybuilder
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeDriver is a database/sql driver which records the executed queries and returns the rows of its backend
+type fakeDriver struct{}
+
+// fakeBackend is the state of a fake database, the DSN of a connection is the name of its backend
+type fakeBackend struct {
+	mu      sync.Mutex
+	queries []string
+	args    [][]driver.Value
+	columns []string
+	rows    [][]driver.Value
+}
+
+var (
+	fakeBackendsMu sync.Mutex
+	fakeBackends   = map[string]*fakeBackend{}
+)
+
+func init() {
+	sql.Register("querybuilder-fake", fakeDriver{})
+}
+
+// openFakeDB opens a database of the fake driver, the queries return columns and rows
+func openFakeDB(t *testing.T, columns []string, rows ...[]driver.Value) (*sql.DB, *fakeBackend) {
+	backend := &fakeBackend{columns: columns, rows: rows}
+	fakeBackendsMu.Lock()
+	fakeBackends[t.Name()] = backend
+	fakeBackendsMu.Unlock()
+
+	db, err := sql.Open("querybuilder-fake", t.Name())
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		db.Close()
+		fakeBackendsMu.Lock()
+		delete(fakeBackends, t.Name())
+		fakeBackendsMu.Unlock()
+	})
+	return db, backend
+}
+
+func (fakeDriver) Open(name string) (driver.Conn, error) {
+	fakeBackendsMu.Lock()
+	defer fakeBackendsMu.Unlock()
+	return &fakeConn{backend: fakeBackends[name]}, nil
+}
+
+type fakeConn struct {
+	backend *fakeBackend
+}
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return &fakeStmt{backend: c.backend, query: query}, nil
+}
+
+func (c *fakeConn) Close() error {
+	return nil
+}
+
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	return fakeTx{}, nil
+}
+
+type fakeTx struct{}
+
+func (fakeTx) Commit() error {
+	return nil
+}
+
+func (fakeTx) Rollback() error {
+	return nil
+}
+
+type fakeStmt struct {
+	backend *fakeBackend
+	query   string
+}
+
+func (s *fakeStmt) Close() error {
+	return nil
+}
+
+func (s *fakeStmt) NumInput() int {
+	return -1
+}
+
+func (s *fakeStmt) record(args []driver.Value) {
+	s.backend.mu.Lock()
+	defer s.backend.mu.Unlock()
+	s.backend.queries = append(s.backend.queries, s.query)
+	s.backend.args = append(s.backend.args, args)
+}
+
+func (s *fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.record(args)
+	return driver.RowsAffected(len(s.backend.rows)), nil
+}
+
+func (s *fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	s.record(args)
+	return &fakeRows{columns: s.backend.columns, rows: s.backend.rows}, nil
+}
+
+type fakeRows struct {
+	columns []string
+	rows    [][]driver.Value
+	next    int
+}
+
+func (r *fakeRows) Columns() []string {
+	return r.columns
+}
+
+func (r *fakeRows) Close() error {
+	return nil
+}
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.next >= len(r.rows) {
+		return io.EOF
+	}
+	copy(dest, r.rows[r.next])
+	r.next++
+	return nil
+}
+
+func TestSelectQuery_QueryContext(t *testing.T) {
+	db, backend := openFakeDB(t, []string{"id", "name"}, []driver.Value{int64(1), "a"}, []driver.Value{int64(2), "b"})
+
+	rows, err := New(DialectPostgres).RunWith(db).Select("table1").Columns("id,name").Where("id>?", 0).QueryContext(context.Background())
+	require.NoError(t, err)
+	defer rows.Close()
+	var names []string
+	for rows.Next() {
+		var id int64
+		var name string
+		require.NoError(t, rows.Scan(&id, &name))
+		names = append(names, name)
+	}
+	require.NoError(t, rows.Err())
+	require.Equal(t, []string{"a", "b"}, names)
+	require.Equal(t, []string{"SELECT id,name FROM table1 WHERE (id>$1)"}, backend.queries)
+	require.Equal(t, [][]driver.Value{{int64(0)}}, backend.args)
+}
+
+func TestSelectQuery_QueryRowContext(t *testing.T) {
+	db, backend := openFakeDB(t, []string{"name"}, []driver.Value{"a"})
+
+	var name string
+	err := Select("table1").Columns("name").Where("id=?", 1).RunWith(db).QueryRowContext(context.Background()).Scan(&name)
+	require.NoError(t, err)
+	require.Equal(t, "a", name)
+	require.Equal(t, []string{"SELECT name FROM table1 WHERE (id=?)"}, backend.queries)
+
+	err = Select("table1").Where("id=?", 1, 2).RunWith(db).QueryRowContext(context.Background()).Scan(&name)
+	require.EqualError(t, err, ErrWrongNumberOfArgs)
+}
+
+func TestInsertQuery_ExecContext(t *testing.T) {
+	db, backend := openFakeDB(t, nil)
+
+	tx, err := db.Begin()
+	require.NoError(t, err)
+	_, err = New(DialectSqlServer).RunWith(tx).Insert("table1").MapValues(map[string]interface{}{"name": "a"}).ExecContext(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, tx.Commit())
+	require.Equal(t, []string{"INSERT INTO table1(name) VALUES(@p1)"}, backend.queries)
+	require.Equal(t, [][]driver.Value{{"a"}}, backend.args)
+}
+
+func TestUpdateAndDeleteQuery_ExecContext(t *testing.T) {
+	db, backend := openFakeDB(t, nil)
+	conn, err := db.Conn(context.Background())
+	require.NoError(t, err)
+	defer conn.Close()
+
+	b := New(DialectMySQL).RunWith(conn)
+	_, err = b.Update("table1").MapValues(map[string]interface{}{"name": "a"}).Where("id=?", 1).ExecContext(context.Background())
+	require.NoError(t, err)
+	_, err = b.Delete("table1").Where("id=?", 1).ExecContext(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []string{"UPDATE table1 SET name=? WHERE (id=?)", "DELETE FROM table1 WHERE (id=?)"}, backend.queries)
+}
+
+func TestRunnerNotSet(t *testing.T) {
+	_, err := Insert("table1").MapValues(map[string]interface{}{"name": "a"}).ExecContext(context.Background())
+	require.EqualError(t, err, ErrRunnerNotSet)
+
+	_, err = Select("table1").QueryContext(context.Background())
+	require.EqualError(t, err, ErrRunnerNotSet)
+
+	err = Delete("table1").Returning("id").QueryRowContext(context.Background()).Scan()
+	require.EqualError(t, err, ErrRunnerNotSet)
+}
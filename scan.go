@@ -0,0 +1,176 @@
+package querybuilder
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// StrictScan makes Scan and ScanAll fail when a result column is not mapped to a struct field
+func (s *SelectQuery) StrictScan() *SelectQuery {
+	newQuery := *s
+	newQuery.strictScan = true
+	return &newQuery
+}
+
+// Scan runs the query and copies the first row into dest, a pointer to a struct.
+// Columns are mapped to fields with the same db tag rules as StructValues. If runner is nil, the runner set by RunWith is used.
+// sql.ErrNoRows is returned when there is no row.
+func (s *SelectQuery) Scan(ctx context.Context, runner Runner, dest interface{}) error {
+	v := reflect.ValueOf(dest)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return errors.New(ErrScanDestination)
+	}
+	rows, err := s.queryWith(ctx, runner)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return err
+		}
+		return sql.ErrNoRows
+	}
+	columns, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+	if err := scanStruct(rows, columns, v.Elem(), s.strictScan); err != nil {
+		return err
+	}
+	return rows.Close()
+}
+
+// ScanAll runs the query and appends every row to dest, a pointer to a slice of structs or of pointers to structs.
+// Columns are mapped to fields with the same db tag rules as StructValues. If runner is nil, the runner set by RunWith is used.
+func (s *SelectQuery) ScanAll(ctx context.Context, runner Runner, dest interface{}) error {
+	v := reflect.ValueOf(dest)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Slice {
+		return errors.New(ErrScanDestination)
+	}
+	slice := v.Elem()
+	elemType := slice.Type().Elem()
+	isPtr := elemType.Kind() == reflect.Ptr
+	if isPtr {
+		elemType = elemType.Elem()
+	}
+	if elemType.Kind() != reflect.Struct {
+		return errors.New(ErrScanDestination)
+	}
+
+	rows, err := s.queryWith(ctx, runner)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+	for rows.Next() {
+		elem := reflect.New(elemType)
+		if err := scanStruct(rows, columns, elem.Elem(), s.strictScan); err != nil {
+			return err
+		}
+		if isPtr {
+			slice.Set(reflect.Append(slice, elem))
+		} else {
+			slice.Set(reflect.Append(slice, elem.Elem()))
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	return rows.Close()
+}
+
+// queryWith runs the query with runner, or with the runner set by RunWith if runner is nil
+func (s *SelectQuery) queryWith(ctx context.Context, runner Runner) (*sql.Rows, error) {
+	if runner == nil {
+		runner = s.runner
+	}
+	query, args, err := s.Build()
+	return queryContext(ctx, runner, query, args, err)
+}
+
+// scanStruct scans the current row into the fields of v which are mapped to the columns
+func scanStruct(rows *sql.Rows, columns []string, v reflect.Value, strict bool) error {
+	fields := structFields(v.Type())
+	dest := make([]interface{}, len(columns))
+	for i, column := range columns {
+		index, ok := fields[column]
+		if !ok {
+			index, ok = fields[strings.ToLower(column)]
+		}
+		if !ok {
+			if strict {
+				return fmt.Errorf("%s: %s", ErrUnmappedColumn, column)
+			}
+			dest[i] = new(interface{})
+			continue
+		}
+		dest[i] = v.FieldByIndex(index).Addr().Interface()
+	}
+	return rows.Scan(dest...)
+}
+
+// structFields returns the index of the field of each column of t.
+// The lower case column is also added, so columns are matched case insensitively when there is no exact match.
+func structFields(t reflect.Type) map[string][]int {
+	fields := make(map[string][]int)
+	walkStructFields(t, nil, func(column string, index []int) {
+		if _, ok := fields[column]; !ok {
+			fields[column] = index
+		}
+		if _, ok := fields[strings.ToLower(column)]; !ok {
+			fields[strings.ToLower(column)] = index
+		}
+	})
+	return fields
+}
+
+// structColumns returns the columns of t in the order of its fields
+func structColumns(t reflect.Type) []string {
+	var columns []string
+	walkStructFields(t, nil, func(column string, index []int) {
+		columns = append(columns, column)
+	})
+	return columns
+}
+
+// walkStructFields calls fn with the column and the index of each field of t, with the same rules as structToMap:
+// the column is the db tag or the field name, fields tagged with - and unexported fields are skipped
+// and the fields of nested structs without a String method, embedded or not, are flattened.
+func walkStructFields(t reflect.Type, parent []int, fn func(column string, index []int)) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" && !field.Anonymous {
+			continue
+		}
+		tag := strings.Split(field.Tag.Get("db"), ",")[0]
+		if tag == "-" {
+			continue
+		}
+		index := append(append([]int{}, parent...), i)
+		if field.Type.Kind() == reflect.Struct {
+			if _, ok := field.Type.MethodByName("String"); !ok {
+				walkStructFields(field.Type, index, fn)
+				continue
+			}
+		}
+		if field.PkgPath != "" {
+			continue
+		}
+		column := tag
+		if column == "" {
+			column = field.Name
+		}
+		fn(column, index)
+	}
+}
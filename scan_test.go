@@ -0,0 +1,115 @@
+package querybuilder
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type scanAddress struct {
+	City    string `db:"city"`
+	Country string
+}
+
+type scanUser struct {
+	ID       int64   `db:"id"`
+	Name     string  `db:"name,omitempty"`
+	Email    *string `db:"email"`
+	Password string  `db:"-"`
+	scanAddress
+}
+
+func TestSelectQuery_Scan(t *testing.T) {
+	db, backend := openFakeDB(t, []string{"id", "name", "email", "city", "country"},
+		[]driver.Value{int64(1), "a", nil, "Berlin", "DE"},
+		[]driver.Value{int64(2), "b", "b@example.com", "Paris", "FR"})
+
+	var user scanUser
+	err := Select("users").Where("id>?", 0).Scan(context.Background(), db, &user)
+	require.NoError(t, err)
+	require.Equal(t, scanUser{ID: 1, Name: "a", scanAddress: scanAddress{City: "Berlin", Country: "DE"}}, user)
+	require.Equal(t, []string{"SELECT * FROM users WHERE (id>?)"}, backend.queries)
+}
+
+func TestSelectQuery_ScanAll(t *testing.T) {
+	db, _ := openFakeDB(t, []string{"id", "email", "extra"},
+		[]driver.Value{int64(1), nil, "x"},
+		[]driver.Value{int64(2), "b@example.com", "y"})
+	email := "b@example.com"
+
+	var users []scanUser
+	err := New(DialectPostgres).RunWith(db).Select("users").ScanAll(context.Background(), nil, &users)
+	require.NoError(t, err)
+	require.Equal(t, []scanUser{{ID: 1}, {ID: 2, Email: &email}}, users)
+
+	var pointers []*scanUser
+	err = Select("users").ScanAll(context.Background(), db, &pointers)
+	require.NoError(t, err)
+	require.Equal(t, []*scanUser{{ID: 1}, {ID: 2, Email: &email}}, pointers)
+}
+
+func TestSelectQuery_ScanErrors(t *testing.T) {
+	db, _ := openFakeDB(t, []string{"id", "password"}, []driver.Value{int64(1), "secret"})
+	ctx := context.Background()
+
+	tests := []struct {
+		name  string
+		query *SelectQuery
+		dest  interface{}
+		all   bool
+		err   string
+	}{
+		{name: "strict unmapped column", query: Select("users").StrictScan(), dest: &scanUser{}, err: ErrUnmappedColumn + ": password"},
+		{name: "strict unmapped column all", query: Select("users").StrictScan(), dest: &[]scanUser{}, all: true, err: ErrUnmappedColumn + ": password"},
+		{name: "not a pointer", query: Select("users"), dest: scanUser{}, err: ErrScanDestination},
+		{name: "not a slice", query: Select("users"), dest: &scanUser{}, all: true, err: ErrScanDestination},
+		{name: "not a slice of structs", query: Select("users"), dest: &[]int64{}, all: true, err: ErrScanDestination},
+		{name: "build error", query: Select(""), dest: &scanUser{}, err: ErrTableIsEmpty},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var err error
+			if tt.all {
+				err = tt.query.ScanAll(ctx, db, tt.dest)
+			} else {
+				err = tt.query.Scan(ctx, db, tt.dest)
+			}
+			require.EqualError(t, err, tt.err)
+		})
+	}
+
+	err := Select("users").Scan(ctx, nil, &scanUser{})
+	require.EqualError(t, err, ErrRunnerNotSet)
+}
+
+func TestSelectQuery_ScanNoRows(t *testing.T) {
+	db, _ := openFakeDB(t, []string{"id"})
+
+	err := Select("users").Scan(context.Background(), db, &scanUser{})
+	require.Equal(t, sql.ErrNoRows, err)
+}
+
+func TestStructRulesMatchStructValues(t *testing.T) {
+	email := "a@example.com"
+	user := scanUser{ID: 1, Name: "a", Email: &email, Password: "secret", scanAddress: scanAddress{City: "Berlin", Country: "DE"}}
+
+	query, args, err := Insert("users").StructValues(user).Build()
+	require.NoError(t, err)
+	require.Equal(t, "INSERT INTO users(id,name,email,city,Country) VALUES(?,?,?,?,?)", query)
+	require.Equal(t, []interface{}{int64(1), "a", email, "Berlin", "DE"}, args)
+
+	query, _, err = Select("users").Columns(user).Build()
+	require.NoError(t, err)
+	require.Equal(t, "SELECT id,name,email,city,Country FROM users", query)
+
+	query, args, err = Update("users").StructValues(struct {
+		ID     int64 `db:"id"`
+		secret string
+	}{ID: 1, secret: "x"}).Build()
+	require.NoError(t, err)
+	require.Equal(t, "UPDATE users SET id=?", query)
+	require.Equal(t, []interface{}{int64(1)}, args)
+}
@@ -1,8 +1,9 @@
 package querybuilder
 
 import (
+	"context"
+	"database/sql"
 	"errors"
-	"fmt"
 	"strconv"
 	"strings"
 )
@@ -45,46 +46,66 @@ func orderDirectionString(direction OrderDirection) string {
 }
 
 type SelectQuery struct {
+	dialect    Dialect
+	ctes       []withClause
 	columns    []columnClause
 	table      string
+	from       Sqlizer
 	joins      []joinClause
 	conditions []whereClause
-	havings    []havingClause
+	havings    []whereClause
 	groupBy    []groupByClause
+	compounds  []compoundClause
 	orderBy    []orderByClause
 	limit      interface{}
 	offset     interface{}
+	keyset     *keysetClause
+	// bindPagination renders limit and offset as placeholders instead of inlining them
+	bindPagination bool
+	quoteMode      QuoteMode
+	runner         Runner
+	strictScan     bool
 }
 
-func (s *SelectQuery) Columns(query string, args ...interface{}) *SelectQuery {
+// Columns adds columns to the query, query is a raw query string with its args or a Sqlizer like a subquery created by As.
+// It can also be a struct, a reflect.Type of a struct or the StructColumns of ColumnsOf, to select the columns mapped to its fields.
+func (s *SelectQuery) Columns(query interface{}, args ...interface{}) *SelectQuery {
 	args, _ = unifyArgs(args...)
+	if columns, ok := structColumnsOf(query); ok {
+		columns.quoter = identifierQuoter{dialect: s.dialect, mode: s.quoteMode}
+		query = columns
+	}
 	column := columnClause{
-		query: query,
-		args:  args,
+		column: toSqlizer(query, args),
 	}
 	newQuery := *s
 	newQuery.columns = append(newQuery.columns, column)
 	return &newQuery
 }
 
-func (s *SelectQuery) Joins(tableName string, on string, joinType JoinType, args ...interface{}) *SelectQuery {
+// Joins adds a join, tableName is a table name or a Sqlizer like a subquery created by As
+func (s *SelectQuery) Joins(tableName interface{}, on interface{}, joinType JoinType, args ...interface{}) *SelectQuery {
 	args, _ = unifyArgs(args...)
 	join := joinClause{
-		tableName: tableName,
-		args:      args,
-		on:        on,
-		joinType:  joinType,
+		table:    tableSqlizer(tableName),
+		on:       toSqlizer(on, args),
+		joinType: joinType,
 	}
 	newQuery := *s
 	newQuery.joins = append(newQuery.joins, join)
 	return &newQuery
 }
 
-func (s *SelectQuery) Where(query string, args ...interface{}) *SelectQuery {
+// JoinsFrom adds a join with the derived table sub with the alias
+func (s *SelectQuery) JoinsFrom(sub *SelectQuery, alias string, on interface{}, joinType JoinType, args ...interface{}) *SelectQuery {
+	return s.Joins(derivedTable{query: sub, alias: alias}, on, joinType, args...)
+}
+
+// Where adds a condition, query is a raw query string with its args or a Sqlizer like Eq or Gt
+func (s *SelectQuery) Where(query interface{}, args ...interface{}) *SelectQuery {
 	args, _ = unifyArgs(args...)
 	condition := whereClause{
-		query: query,
-		args:  args,
+		condition: toSqlizer(query, args),
 	}
 	newQuery := *s
 
@@ -92,11 +113,47 @@ func (s *SelectQuery) Where(query string, args ...interface{}) *SelectQuery {
 	return &newQuery
 }
 
-func (s *SelectQuery) Having(query string, args ...interface{}) *SelectQuery {
+// OrWhere adds a condition which is joined to the previous conditions with OR
+func (s *SelectQuery) OrWhere(query interface{}, args ...interface{}) *SelectQuery {
 	args, _ = unifyArgs(args...)
-	having := havingClause{
-		query: query,
-		args:  args,
+	condition := whereClause{
+		condition: toSqlizer(query, args),
+		or:        true,
+	}
+	newQuery := *s
+
+	newQuery.conditions = append(newQuery.conditions, condition)
+	return &newQuery
+}
+
+// WhereGroup adds a parenthesized group of conditions joined with AND, the group is filled by fn
+func (s *SelectQuery) WhereGroup(fn func(g *ConditionGroup)) *SelectQuery {
+	condition := whereClause{
+		condition: newConditionGroup(fn),
+	}
+	newQuery := *s
+
+	newQuery.conditions = append(newQuery.conditions, condition)
+	return &newQuery
+}
+
+// OrWhereGroup adds a parenthesized group of conditions joined with OR, the group is filled by fn
+func (s *SelectQuery) OrWhereGroup(fn func(g *ConditionGroup)) *SelectQuery {
+	condition := whereClause{
+		condition: newConditionGroup(fn),
+		or:        true,
+	}
+	newQuery := *s
+
+	newQuery.conditions = append(newQuery.conditions, condition)
+	return &newQuery
+}
+
+// Having adds a HAVING condition, query is a raw query string with its args or a Sqlizer like Eq or Gt
+func (s *SelectQuery) Having(query interface{}, args ...interface{}) *SelectQuery {
+	args, _ = unifyArgs(args...)
+	having := whereClause{
+		condition: toSqlizer(query, args),
 	}
 	newQuery := *s
 
@@ -124,67 +181,191 @@ func (s *SelectQuery) Order(column string, direction OrderDirection) *SelectQuer
 }
 
 func (s *SelectQuery) Limit(limit interface{}) *SelectQuery {
-	var l int64
-	var err error
-
-	switch v := limit.(type) {
-	case int8:
-		l = int64(v)
-	case int16:
-		l = int64(v)
-	case int32:
-		l = int64(v)
-	case int64:
-		l = v
-	case int:
-		l = int64(v)
-	case string:
-		l, err = strconv.ParseInt(v, 10, 64)
-		if err != nil {
-			l = 0
+	newQuery := *s
+	newQuery.limit = toInt64(limit)
+	return &newQuery
+}
+
+func (s *SelectQuery) Offset(offset interface{}) *SelectQuery {
+	newQuery := *s
+	newQuery.offset = toInt64(offset)
+	return &newQuery
+}
+
+// After selects the rows which come after the cursor in the order of the query, for keyset pagination.
+// The cursor has the values of the ORDER BY columns of the last row of the previous page.
+func (s *SelectQuery) After(cursor Cursor) *SelectQuery {
+	newQuery := *s
+	newQuery.keyset = &keysetClause{cursor: cursor}
+	return &newQuery
+}
+
+// Before selects the rows which come before the cursor in the order of the query, for keyset pagination.
+// The order of the query is reversed to select the closest rows first, so the rows have to be reversed after reading them.
+func (s *SelectQuery) Before(cursor Cursor) *SelectQuery {
+	newQuery := *s
+	newQuery.keyset = &keysetClause{cursor: cursor, before: true}
+	return &newQuery
+}
+
+// BindPagination renders limit and offset as placeholders with their values as args instead of inlining them
+func (s *SelectQuery) BindPagination() *SelectQuery {
+	newQuery := *s
+	newQuery.bindPagination = true
+	return &newQuery
+}
+
+// pagination renders the limit and offset for the dialect, see Dialect.LimitOffset
+func (s *SelectQuery) pagination() (top Sqlizer, clause Sqlizer, err error) {
+	var limit, offset Sqlizer
+	if s.limit != nil {
+		l, ok := s.limit.(int64)
+		if !ok {
+			return nil, nil, errors.New(ErrLimitNotInteger)
 		}
-	default:
-		l = 0
+		limit = s.paginationValue(l)
+	}
+	if s.offset != nil {
+		o, ok := s.offset.(int64)
+		if !ok {
+			return nil, nil, errors.New(ErrOffsetNotInteger)
+		}
+		offset = s.paginationValue(o)
+	}
+	top, clause, err = orDefault(s.dialect).LimitOffset(limit, offset, len(s.orderBy) > 0)
+	if err != nil {
+		return nil, nil, err
+	}
+	if top != nil && len(s.compounds) > 0 {
+		// TOP would only limit the first query of the set operation
+		return nil, nil, errors.New(ErrOrderByRequired)
 	}
+	return top, clause, nil
+}
+
+func (s *SelectQuery) paginationValue(value int64) Sqlizer {
+	if s.bindPagination {
+		return Expr("?", value)
+	}
+	return Expr(strconv.FormatInt(value, 10))
+}
 
+// With adds a common table expression, query is a raw query string with its args or a Sqlizer like a SelectQuery
+func (s *SelectQuery) With(name string, query interface{}, args ...interface{}) *SelectQuery {
+	args, _ = unifyArgs(args...)
+	cte := withClause{
+		name:  name,
+		query: toSqlizer(query, args),
+	}
 	newQuery := *s
-	newQuery.limit = l
+	newQuery.ctes = append(newQuery.ctes, cte)
 	return &newQuery
 }
 
-func (s *SelectQuery) Offset(offset interface{}) *SelectQuery {
-	var o int64
-	var err error
-
-	switch v := offset.(type) {
-	case int8:
-		o = int64(v)
-	case int16:
-		o = int64(v)
-	case int32:
-		o = int64(v)
-	case int64:
-		o = v
-	case int:
-		o = int64(v)
-	case string:
-		o, err = strconv.ParseInt(v, 10, 64)
-		if err != nil {
-			o = 0
-		}
-	default:
-		o = 0
+// WithRecursive adds a recursive common table expression with its columns
+func (s *SelectQuery) WithRecursive(name string, columns string, query interface{}, args ...interface{}) *SelectQuery {
+	args, _ = unifyArgs(args...)
+	cte := withClause{
+		name:      name,
+		columns:   columns,
+		query:     toSqlizer(query, args),
+		recursive: true,
 	}
+	newQuery := *s
+	newQuery.ctes = append(newQuery.ctes, cte)
+	return &newQuery
+}
+
+// Union combines the result of the query with the result of other, removing duplicate rows.
+// Order, Limit and Offset of the query apply to the combined result.
+func (s *SelectQuery) Union(other *SelectQuery) *SelectQuery {
+	return s.compound("UNION", other)
+}
 
+// UnionAll combines the result of the query with the result of other, keeping duplicate rows.
+// Order, Limit and Offset of the query apply to the combined result.
+func (s *SelectQuery) UnionAll(other *SelectQuery) *SelectQuery {
+	return s.compound("UNION ALL", other)
+}
+
+// Intersect keeps the rows which are in the result of the query and the result of other.
+// Order, Limit and Offset of the query apply to the combined result.
+func (s *SelectQuery) Intersect(other *SelectQuery) *SelectQuery {
+	return s.compound("INTERSECT", other)
+}
+
+// Except keeps the rows of the result of the query which are not in the result of other.
+// It's rendered as MINUS for dialects without EXCEPT. Order, Limit and Offset of the query apply to the combined result.
+func (s *SelectQuery) Except(other *SelectQuery) *SelectQuery {
+	return s.compound("EXCEPT", other)
+}
+
+func (s *SelectQuery) compound(operator string, other *SelectQuery) *SelectQuery {
+	if other == nil {
+		return s
+	}
+	clause := compoundClause{
+		operator: operator,
+		query:    other,
+	}
 	newQuery := *s
-	newQuery.offset = o
+	newQuery.compounds = append(newQuery.compounds, clause)
 	return &newQuery
 }
 
+// isCompoundOrPaginated reports whether the query has to be parenthesized when it's part of a set operation
+func (s *SelectQuery) isCompoundOrPaginated() bool {
+	return len(s.compounds) > 0 || len(s.orderBy) > 0 || s.limit != nil || s.offset != nil || len(s.ctes) > 0
+}
+
+// Build builds the query and its arguments, placeholders are rendered for the query's dialect
 func (s *SelectQuery) Build() (string, []interface{}, error) {
-	if s.table == "" {
+	query, args, err := s.build()
+	if err != nil {
+		return "", nil, err
+	}
+	return rebind(orDefault(s.dialect), query), args, nil
+}
+
+// RunWith sets the runner which executes the query, like a *sql.DB, *sql.Tx or *sql.Conn
+func (s *SelectQuery) RunWith(runner Runner) *SelectQuery {
+	newQuery := *s
+	newQuery.runner = runner
+	return &newQuery
+}
+
+// QueryContext builds the query and runs it with the runner set by RunWith
+func (s *SelectQuery) QueryContext(ctx context.Context) (*sql.Rows, error) {
+	query, args, err := s.Build()
+	return queryContext(ctx, s.runner, query, args, err)
+}
+
+// QueryRowContext builds the query and runs it with the runner set by RunWith, the error of building the query is returned by Row.Scan
+func (s *SelectQuery) QueryRowContext(ctx context.Context) *Row {
+	query, args, err := s.Build()
+	return queryRowContext(ctx, s.runner, query, args, err)
+}
+
+// ToSql builds the query with QUESTION placeholders, so the query can be used as a subquery of another query
+func (s *SelectQuery) ToSql() (string, []interface{}, error) {
+	return s.build()
+}
+
+// As wraps the query in parentheses with an alias, to use it as a column or a join table of another query.
+// As a join table, the alias follows the query without AS, like JoinsFrom.
+func (s *SelectQuery) As(alias string) Sqlizer {
+	return aliasExpr{query: s, alias: alias}
+}
+
+// build builds the query with QUESTION placeholders
+func (s *SelectQuery) build() (string, []interface{}, error) {
+	if s.table == "" && s.from == nil {
 		return "", nil, errors.New(ErrTableIsEmpty)
 	}
+	top, pagination, err := s.pagination()
+	if err != nil {
+		return "", nil, err
+	}
 	var args []interface{}
 	var columns string
 	//
@@ -192,8 +373,12 @@ func (s *SelectQuery) Build() (string, []interface{}, error) {
 	if len(s.columns) > 0 {
 		var columnsSlice []string
 		for _, column := range s.columns {
-			columnsSlice = append(columnsSlice, column.query)
-			args = append(args, column.args...)
+			columnQuery, columnArgs, err := renderSql(s.dialect, column.column)
+			if err != nil {
+				return "", nil, err
+			}
+			columnsSlice = append(columnsSlice, columnQuery)
+			args = append(args, columnArgs...)
 		}
 		columns = strings.Join(columnsSlice, ",")
 	} else {
@@ -201,26 +386,67 @@ func (s *SelectQuery) Build() (string, []interface{}, error) {
 	}
 	// add columns
 	query := "SELECT " + columns
+	if top != nil {
+		topQuery, topArgs, err := top.ToSql()
+		if err != nil {
+			return "", nil, err
+		}
+		query = "SELECT " + topQuery + " " + columns
+		args = append(topArgs, args...)
+	}
 	//
-	// add table name
-	query = query + " FROM " + s.table
+	// add table name or derived table
+	if s.from != nil {
+		from, fromArgs, err := renderSql(s.dialect, s.from)
+		if err != nil {
+			return "", nil, err
+		}
+		query = query + " FROM " + from
+		args = append(args, fromArgs...)
+	} else {
+		table, err := identifierQuoter{dialect: s.dialect, mode: s.quoteMode}.table(s.table)
+		if err != nil {
+			return "", nil, err
+		}
+		query = query + " FROM " + table
+	}
 	//
 	// add joins
 	if len(s.joins) > 0 {
-		for _, join := range s.joins {
-			args = append(args, join.args...)
-			query = query + " " + joinTypeString(join.joinType) + " " + join.tableName + " ON " + join.on
+		joins, joinArgs, err := buildJoins(s.dialect, s.joins)
+		if err != nil {
+			return "", nil, err
 		}
+		query = query + joins
+		args = append(args, joinArgs...)
 	}
 	//
 	// check for where part
-	if len(s.conditions) > 0 {
-		var conditionsSlice []string
-		for _, condition := range s.conditions {
-			conditionsSlice = append(conditionsSlice, fmt.Sprintf("(%s)", condition.query))
-			args = append(args, condition.args...)
+	conditions := s.conditions
+	orderBy := s.orderBy
+	if s.keyset != nil {
+		// the cursor would only filter the first query while ORDER BY sorts the compound result
+		if len(s.compounds) > 0 {
+			return "", nil, errors.New(ErrKeysetWithCompound)
+		}
+		if s.keyset.before {
+			orderBy = reverseOrder(orderBy)
+		}
+		keyset, err := keysetCondition(orDefault(s.dialect), orderBy, s.keyset.cursor)
+		if err != nil {
+			return "", nil, err
+		}
+		conditions = append(groupConditions(conditions), whereClause{condition: keyset})
+	}
+	if len(conditions) > 0 {
+		conditions, conditionArgs, err := buildConditions(s.dialect, conditions)
+		if err != nil {
+			return "", nil, err
+		}
+		if conditions != "" {
+			query = query + " WHERE " + conditions
+			args = append(args, conditionArgs...)
 		}
-		query = query + " WHERE " + strings.Join(conditionsSlice, " AND ")
 	}
 	//
 	// add group by
@@ -234,43 +460,65 @@ func (s *SelectQuery) Build() (string, []interface{}, error) {
 	//
 	// add having
 	if len(s.havings) > 0 {
-		var havingSlice []string
-		for _, having := range s.havings {
-			havingSlice = append(havingSlice, fmt.Sprintf("(%s)", having.query))
-			args = append(args, having.args...)
+		havings, havingArgs, err := buildConditions(s.dialect, s.havings)
+		if err != nil {
+			return "", nil, err
+		}
+		if havings != "" {
+			query = query + " HAVING " + havings
+			args = append(args, havingArgs...)
 		}
-		query = query + " HAVING " + strings.Join(havingSlice, " AND ")
 	}
 	//
-	// add order by
-	if len(s.orderBy) > 0 {
-		var orderBySlice []string
-		for _, orderBy := range s.orderBy {
-			orderBySlice = append(orderBySlice, orderBy.field+" "+orderDirectionString(orderBy.direction))
+	// add set operations, ORDER BY, LIMIT and OFFSET below apply to the compound result
+	for _, compound := range s.compounds {
+		compoundQuery, compoundArgs, err := compound.query.build()
+		if err != nil {
+			return "", nil, err
+		}
+		if compound.query.isCompoundOrPaginated() {
+			if orDefault(s.dialect).Supports(FeatureParenthesizedCompound) {
+				compoundQuery = "(" + compoundQuery + ")"
+			} else {
+				compoundQuery = "SELECT * FROM (" + compoundQuery + ")"
+			}
+		}
+		operator := compound.operator
+		if operator == "EXCEPT" && !orDefault(s.dialect).Supports(FeatureExcept) {
+			operator = "MINUS"
 		}
-		query = query + " ORDER BY " + strings.Join(orderBySlice, ",")
+		query = query + " " + operator + " " + compoundQuery
+		args = append(args, compoundArgs...)
 	}
 	//
-	// add limit
-	if s.limit != nil {
-		if limit, ok := s.limit.(int64); ok {
-			query = query + fmt.Sprintf(" LIMIT %d", limit)
-		} else {
-			return "", nil, errors.New(ErrLimitNotInteger)
+	// add order by
+	if len(orderBy) > 0 {
+		query = query + " ORDER BY " + buildOrderBy(orderBy)
+	}
+	//
+	// add limit and offset
+	if pagination != nil {
+		paginationQuery, paginationArgs, err := pagination.ToSql()
+		if err != nil {
+			return "", nil, err
 		}
+		query = query + " " + paginationQuery
+		args = append(args, paginationArgs...)
 	}
+
 	//
-	// add offset
-	if s.offset != nil {
-		if offset, ok := s.offset.(int64); ok {
-			query = query + fmt.Sprintf(" OFFSET %d", offset)
-		} else {
-			return "", nil, errors.New(ErrOffsetNotInteger)
+	// add common table expressions
+	if len(s.ctes) > 0 {
+		with, withArgs, err := buildWith(orDefault(s.dialect), s.ctes)
+		if err != nil {
+			return "", nil, err
 		}
+		query = with + query
+		args = append(withArgs, args...)
 	}
 
 	// compare the number of args and ? in tableName
-	if len(args) != strings.Count(query, "?") {
+	if len(args) != countPlaceholders(s.dialect, query) {
 		return "", nil, errors.New(ErrWrongNumberOfArgs)
 	}
 	//
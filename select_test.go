@@ -2,6 +2,7 @@ package querybuilder
 
 import (
 	"errors"
+	"reflect"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -155,6 +156,13 @@ func TestSelectQuery_Build(t *testing.T) {
 			wantArgs:       []interface{}{120},
 			wantErr:        nil,
 		},
+		{
+			name:           "test20",
+			query:          Select("table1").Where("note <> 'what?' AND id=?", 120).Where("data ?? ?", "key"),
+			wantBuiltQuery: "SELECT * FROM table1 WHERE (note <> 'what?' AND id=?) AND (data ? ?)",
+			wantArgs:       []interface{}{120, "key"},
+			wantErr:        nil,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -282,3 +290,500 @@ func TestSelectQuery_Rebind(t *testing.T) {
 		})
 	}
 }
+
+func TestSelectQuery_BuildDialect(t *testing.T) {
+	tests := []struct {
+		name    string
+		dialect Dialect
+		want    string
+	}{
+		{
+			name:    "test Postgres",
+			dialect: DialectPostgres,
+			want:    "SELECT c1,COALESCE(c2,$1) AS c2 FROM table1 JOIN table2 ON table1.id=table2.t_id AND table2.kind=$2 WHERE (c1=$3) AND (c3 IN ($4,$5)) GROUP BY c1 HAVING (SUM(c4)>$6)",
+		},
+		{
+			name:    "test MySQL",
+			dialect: DialectMySQL,
+			want:    "SELECT c1,COALESCE(c2,?) AS c2 FROM table1 JOIN table2 ON table1.id=table2.t_id AND table2.kind=? WHERE (c1=?) AND (c3 IN (?,?)) GROUP BY c1 HAVING (SUM(c4)>?)",
+		},
+		{
+			name:    "test oci8",
+			dialect: DialectOCI8,
+			want:    "SELECT c1,COALESCE(c2,:arg1) AS c2 FROM table1 JOIN table2 ON table1.id=table2.t_id AND table2.kind=:arg2 WHERE (c1=:arg3) AND (c3 IN (:arg4,:arg5)) GROUP BY c1 HAVING (SUM(c4)>:arg6)",
+		},
+		{
+			name:    "test SqlServer",
+			dialect: DialectSqlServer,
+			want:    "SELECT c1,COALESCE(c2,@p1) AS c2 FROM table1 JOIN table2 ON table1.id=table2.t_id AND table2.kind=@p2 WHERE (c1=@p3) AND (c3 IN (@p4,@p5)) GROUP BY c1 HAVING (SUM(c4)>@p6)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, args, err := New(tt.dialect).Select("table1").
+				Columns("c1,COALESCE(c2,?) AS c2", "-").
+				Joins("table2", "table1.id=table2.t_id AND table2.kind=?", JoinInner, "k").
+				Where("c1=?", 1).
+				Where(In("c3", 2, 3)).
+				Group("c1").
+				Having("SUM(c4)>?", 100).
+				Build()
+			require.NoError(t, err)
+			require.Equal(t, tt.want, query)
+			require.Equal(t, []interface{}{"-", "k", 1, 2, 3, 100}, args)
+		})
+	}
+}
+
+func TestSelectQuery_Subqueries(t *testing.T) {
+	qb := New(DialectPostgres)
+	orders := qb.Select("orders").Columns("user_id").Where("total>?", 100)
+	orderCount := qb.Select("orders o").Columns("COUNT(*)").Where("o.user_id=u.id AND o.status=?", "paid")
+	tests := []struct {
+		name      string
+		query     queryBuilder
+		wantQuery string
+		wantArgs  []interface{}
+		wantErr   error
+	}{
+		{
+			name:      "where",
+			query:     qb.Select("users").Where("active=?", true).Where("id IN (?)", orders),
+			wantQuery: "SELECT * FROM users WHERE (active=$1) AND (id IN (SELECT user_id FROM orders WHERE (total>$2)))",
+			wantArgs:  []interface{}{true, 100},
+		},
+		{
+			name:      "In",
+			query:     qb.Select("users").Where(In("id", orders)).Where("name=?", "Omid"),
+			wantQuery: "SELECT * FROM users WHERE (id IN (SELECT user_id FROM orders WHERE (total>$1))) AND (name=$2)",
+			wantArgs:  []interface{}{100, "Omid"},
+		},
+		{
+			name:      "Eq and Gt",
+			query:     qb.Select("users").Where(Eq{"id": orders}).Where(Gt("age", qb.Select("limits").Columns("MIN(age)").Where("kind=?", "adult"))),
+			wantQuery: "SELECT * FROM users WHERE (id IN (SELECT user_id FROM orders WHERE (total>$1))) AND (age>(SELECT MIN(age) FROM limits WHERE (kind=$2)))",
+			wantArgs:  []interface{}{100, "adult"},
+		},
+		{
+			name:      "column",
+			query:     qb.Select("users u").Columns("u.id,COALESCE(u.name,?) AS name", "-").Columns(orderCount.As("cnt")).Where("u.active=?", true),
+			wantQuery: "SELECT u.id,COALESCE(u.name,$1) AS name,(SELECT COUNT(*) FROM orders o WHERE (o.user_id=u.id AND o.status=$2)) AS cnt FROM users u WHERE (u.active=$3)",
+			wantArgs:  []interface{}{"-", "paid", true},
+		},
+		{
+			name: "join",
+			query: qb.Select("users u").Columns("u.id,x.total").
+				Joins(qb.Select("orders").Columns("user_id,SUM(total) AS total").Where("status=?", "paid").Group("user_id").As("x"), "x.user_id=u.id AND x.total>?", JoinLeft, 10).
+				Where("u.id=?", 1),
+			wantQuery: "SELECT u.id,x.total FROM users u LEFT JOIN (SELECT user_id,SUM(total) AS total FROM orders WHERE (status=$1) GROUP BY user_id) x ON x.user_id=u.id AND x.total>$2 WHERE (u.id=$3)",
+			wantArgs:  []interface{}{"paid", 10, 1},
+		},
+		{
+			name: "oracle join",
+			query: New(DialectOCI8).Select("users u").Columns("u.id,s.total").
+				Joins(New(DialectOCI8).Select("orders").Columns("user_id,SUM(total) total").Where("status=?", "paid").Group("user_id").As("s"), "s.user_id=u.id", JoinInner),
+			wantQuery: "SELECT u.id,s.total FROM users u JOIN (SELECT user_id,SUM(total) total FROM orders WHERE (status=:arg1) GROUP BY user_id) s ON s.user_id=u.id",
+			wantArgs:  []interface{}{"paid"},
+		},
+		{
+			name:      "update",
+			query:     qb.Update("users").MapValues(map[string]interface{}{"vip": true}).Where("id IN (?)", orders),
+			wantQuery: "UPDATE users SET vip=$1 WHERE (id IN (SELECT user_id FROM orders WHERE (total>$2)))",
+			wantArgs:  []interface{}{true, 100},
+		},
+		{
+			name:      "delete",
+			query:     qb.Delete("users").Where(In("id", orders)),
+			wantQuery: "DELETE FROM users WHERE (id IN (SELECT user_id FROM orders WHERE (total>$1)))",
+			wantArgs:  []interface{}{100},
+		},
+		{
+			name:    "subquery error",
+			query:   qb.Select("users").Where("id IN (?)", qb.Select("")),
+			wantErr: errors.New(ErrTableIsEmpty),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, args, err := tt.query.Build()
+			require.Equal(t, tt.wantErr, err)
+			require.Equal(t, tt.wantQuery, query)
+			require.Equal(t, tt.wantArgs, args)
+		})
+	}
+}
+
+func TestSelectFrom(t *testing.T) {
+	qb := New(DialectPostgres)
+	totals := qb.Select("orders").Columns("user_id,SUM(total) AS total").Where("status=?", "paid").Group("user_id").Having("SUM(total)>?", 100)
+	tests := []struct {
+		name      string
+		query     *SelectQuery
+		wantQuery string
+		wantArgs  []interface{}
+		wantErr   error
+	}{
+		{
+			name:      "derived table",
+			query:     qb.SelectFrom(totals, "t").Columns("t.user_id,t.total").Where("t.total<?", 1000).Order("t.total", OrderDesc).Limit(10).Offset(20),
+			wantQuery: "SELECT t.user_id,t.total FROM (SELECT user_id,SUM(total) AS total FROM orders WHERE (status=$1) GROUP BY user_id HAVING (SUM(total)>$2)) t WHERE (t.total<$3) ORDER BY t.total DESC LIMIT 10 OFFSET 20",
+			wantArgs:  []interface{}{"paid", 100, 1000},
+		},
+		{
+			name:      "column args before derived table args",
+			query:     qb.SelectFrom(totals, "t").Columns("COALESCE(t.total,?)", 0),
+			wantQuery: "SELECT COALESCE(t.total,$1) FROM (SELECT user_id,SUM(total) AS total FROM orders WHERE (status=$2) GROUP BY user_id HAVING (SUM(total)>$3)) t",
+			wantArgs:  []interface{}{0, "paid", 100},
+		},
+		{
+			name:      "join derived table",
+			query:     qb.Select("users u").Columns("u.id,t.total").JoinsFrom(totals, "t", "t.user_id=u.id", JoinInner).Where("u.active=?", true),
+			wantQuery: "SELECT u.id,t.total FROM users u JOIN (SELECT user_id,SUM(total) AS total FROM orders WHERE (status=$1) GROUP BY user_id HAVING (SUM(total)>$2)) t ON t.user_id=u.id WHERE (u.active=$3)",
+			wantArgs:  []interface{}{"paid", 100, true},
+		},
+		{
+			name:      "default builder",
+			query:     SelectFrom(Select("table1").Where("a=?", 1), "x"),
+			wantQuery: "SELECT * FROM (SELECT * FROM table1 WHERE (a=?)) x",
+			wantArgs:  []interface{}{1},
+		},
+		{
+			name:    "nil subquery",
+			query:   SelectFrom(nil, "x"),
+			wantErr: errors.New(ErrTableIsEmpty),
+		},
+		{
+			name:    "subquery error",
+			query:   SelectFrom(Select(""), "x"),
+			wantErr: errors.New(ErrTableIsEmpty),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, args, err := tt.query.Build()
+			require.Equal(t, tt.wantErr, err)
+			require.Equal(t, tt.wantQuery, query)
+			require.Equal(t, tt.wantArgs, args)
+		})
+	}
+}
+
+func TestWith(t *testing.T) {
+	qb := New(DialectPostgres)
+	recent := qb.Select("orders").Columns("id,user_id").Where("created_at>?", "2024-01-01")
+	tests := []struct {
+		name      string
+		query     queryBuilder
+		wantQuery string
+		wantArgs  []interface{}
+		wantErr   error
+	}{
+		{
+			name:      "select",
+			query:     qb.Select("recent").Columns("COALESCE(user_id,?)", 0).With("recent", recent).Where("id>?", 10),
+			wantQuery: "WITH recent AS (SELECT id,user_id FROM orders WHERE (created_at>$1)) SELECT COALESCE(user_id,$2) FROM recent WHERE (id>$3)",
+			wantArgs:  []interface{}{"2024-01-01", 0, 10},
+		},
+		{
+			name: "select many",
+			query: qb.Select("a").Joins("b", "a.id=b.id", JoinInner).
+				With("a", "SELECT * FROM t1 WHERE x=?", 1).
+				With("b", qb.Select("t2").Where("y=?", 2)),
+			wantQuery: "WITH a AS (SELECT * FROM t1 WHERE x=$1),b AS (SELECT * FROM t2 WHERE (y=$2)) SELECT * FROM a JOIN b ON a.id=b.id",
+			wantArgs:  []interface{}{1, 2},
+		},
+		{
+			name: "recursive",
+			query: qb.Select("tree").
+				WithRecursive("tree", "id,parent_id", "SELECT id,parent_id FROM nodes WHERE id=? UNION ALL SELECT n.id,n.parent_id FROM nodes n JOIN tree t ON n.parent_id=t.id", 1),
+			wantQuery: "WITH RECURSIVE tree(id,parent_id) AS (SELECT id,parent_id FROM nodes WHERE id=$1 UNION ALL SELECT n.id,n.parent_id FROM nodes n JOIN tree t ON n.parent_id=t.id) SELECT * FROM tree",
+			wantArgs:  []interface{}{1},
+		},
+		{
+			name: "recursive without keyword",
+			query: New(DialectSqlServer).Select("tree").
+				With("roots", "SELECT id FROM nodes WHERE parent_id IS NULL").
+				WithRecursive("tree", "id", "SELECT id FROM roots UNION ALL SELECT n.id FROM nodes n JOIN tree t ON n.parent_id=t.id"),
+			wantQuery: "WITH roots AS (SELECT id FROM nodes WHERE parent_id IS NULL),tree(id) AS (SELECT id FROM roots UNION ALL SELECT n.id FROM nodes n JOIN tree t ON n.parent_id=t.id) SELECT * FROM tree",
+		},
+		{
+			name:      "insert",
+			query:     qb.Insert("archive").With("recent", recent).MapValues(map[string]interface{}{"a": 1}),
+			wantQuery: "WITH recent AS (SELECT id,user_id FROM orders WHERE (created_at>$1)) INSERT INTO archive(a) VALUES($2)",
+			wantArgs:  []interface{}{"2024-01-01", 1},
+		},
+		{
+			name:      "update",
+			query:     qb.Update("users").With("recent", recent).MapValues(map[string]interface{}{"active": true}).Where("id IN (SELECT user_id FROM recent)"),
+			wantQuery: "WITH recent AS (SELECT id,user_id FROM orders WHERE (created_at>$1)) UPDATE users SET active=$2 WHERE (id IN (SELECT user_id FROM recent))",
+			wantArgs:  []interface{}{"2024-01-01", true},
+		},
+		{
+			name:      "delete",
+			query:     qb.Delete("orders").With("recent", recent).Where("id NOT IN (SELECT id FROM recent) AND user_id=?", 5),
+			wantQuery: "WITH recent AS (SELECT id,user_id FROM orders WHERE (created_at>$1)) DELETE FROM orders WHERE (id NOT IN (SELECT id FROM recent) AND user_id=$2)",
+			wantArgs:  []interface{}{"2024-01-01", 5},
+		},
+		{
+			name:    "error",
+			query:   qb.Delete("orders").With("recent", qb.Select("")),
+			wantErr: errors.New(ErrTableIsEmpty),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, args, err := tt.query.Build()
+			require.Equal(t, tt.wantErr, err)
+			require.Equal(t, tt.wantQuery, query)
+			require.Equal(t, tt.wantArgs, args)
+		})
+	}
+}
+
+func TestWithDialects(t *testing.T) {
+	cte := Select("s").Where("x=?", 1)
+	tests := []struct {
+		name      string
+		query     queryBuilder
+		wantQuery string
+		wantArgs  []interface{}
+		wantErr   error
+	}{
+		{
+			name:      "postgres insert select",
+			query:     New(DialectPostgres).Insert("t").With("c", cte).Columns("id").FromSelect(Select("c").Where("y=?", 2)),
+			wantQuery: "WITH c AS (SELECT * FROM s WHERE (x=$1)) INSERT INTO t(id) SELECT * FROM c WHERE (y=$2)",
+			wantArgs:  []interface{}{1, 2},
+		},
+		{
+			name:      "sqlite insert values",
+			query:     New(DialectSqlite3).Insert("t").With("c", cte).MapValues(map[string]interface{}{"id": 2}),
+			wantQuery: "WITH c AS (SELECT * FROM s WHERE (x=?)) INSERT INTO t(id) VALUES(?)",
+			wantArgs:  []interface{}{1, 2},
+		},
+		{
+			name:      "mysql insert select",
+			query:     New(DialectMySQL).Insert("t").With("c", cte).Columns("id").FromSelect(Select("c").Where("y=?", 2)),
+			wantQuery: "INSERT INTO t(id) WITH c AS (SELECT * FROM s WHERE (x=?)) SELECT * FROM c WHERE (y=?)",
+			wantArgs:  []interface{}{1, 2},
+		},
+		{
+			name:    "mysql insert values",
+			query:   New(DialectMySQL).Insert("t").With("c", cte).MapValues(map[string]interface{}{"id": 2}),
+			wantErr: errors.New(ErrWithNotSupported),
+		},
+		{
+			name:      "mysql update",
+			query:     New(DialectMySQL).Update("t").With("c", cte).MapValues(map[string]interface{}{"a": 2}).Where("id IN (SELECT id FROM c)"),
+			wantQuery: "WITH c AS (SELECT * FROM s WHERE (x=?)) UPDATE t SET a=? WHERE (id IN (SELECT id FROM c))",
+			wantArgs:  []interface{}{1, 2},
+		},
+		{
+			name:      "oracle insert select",
+			query:     New(DialectOCI8).Insert("t").With("c", cte).Columns("id").FromSelect(Select("c")),
+			wantQuery: "INSERT INTO t(id) WITH c AS (SELECT * FROM s WHERE (x=:arg1)) SELECT * FROM c",
+			wantArgs:  []interface{}{1},
+		},
+		{
+			name:      "oracle merge select",
+			query:     New(DialectOCI8).Insert("t").With("c", cte).Columns("id").FromSelect(Select("c")).OnConflict("id").DoNothing(),
+			wantQuery: "MERGE INTO t target USING (WITH c AS (SELECT * FROM s WHERE (x=:arg1)) SELECT * FROM c) source ON (target.id=source.id) WHEN NOT MATCHED THEN INSERT(id) VALUES(source.id)",
+			wantArgs:  []interface{}{1},
+		},
+		{
+			name:    "oracle update",
+			query:   New(DialectOCI8).Update("t").With("c", cte).MapValues(map[string]interface{}{"a": 2}),
+			wantErr: errors.New(ErrWithNotSupported),
+		},
+		{
+			name:    "oracle delete",
+			query:   New(DialectOCI8).Delete("t").With("c", cte),
+			wantErr: errors.New(ErrWithNotSupported),
+		},
+		{
+			name:      "sql server delete",
+			query:     New(DialectSqlServer).Delete("t").With("c", cte).Where("id IN (SELECT id FROM c)"),
+			wantQuery: "WITH c AS (SELECT * FROM s WHERE (x=@p1)) DELETE FROM t WHERE (id IN (SELECT id FROM c))",
+			wantArgs:  []interface{}{1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, args, err := tt.query.Build()
+			require.Equal(t, tt.wantErr, err)
+			require.Equal(t, tt.wantQuery, query)
+			require.Equal(t, tt.wantArgs, args)
+		})
+	}
+}
+
+func TestSelectQuery_SetOperations(t *testing.T) {
+	qb := New(DialectPostgres)
+	customers := qb.Select("customers").Columns("name,email").Where("country=?", "NL")
+	suppliers := qb.Select("suppliers").Columns("name,email").Where("active=?", true)
+	tests := []struct {
+		name      string
+		query     *SelectQuery
+		wantQuery string
+		wantArgs  []interface{}
+		wantErr   error
+	}{
+		{
+			name:      "union",
+			query:     customers.Union(suppliers),
+			wantQuery: "SELECT name,email FROM customers WHERE (country=$1) UNION SELECT name,email FROM suppliers WHERE (active=$2)",
+			wantArgs:  []interface{}{"NL", true},
+		},
+		{
+			name:      "union all with order and limit",
+			query:     customers.UnionAll(suppliers).Order("name", OrderAsc).Limit(10).Offset(5),
+			wantQuery: "SELECT name,email FROM customers WHERE (country=$1) UNION ALL SELECT name,email FROM suppliers WHERE (active=$2) ORDER BY name ASC LIMIT 10 OFFSET 5",
+			wantArgs:  []interface{}{"NL", true},
+		},
+		{
+			name:      "intersect and except",
+			query:     customers.Intersect(suppliers).Except(qb.Select("blocked").Columns("name,email").Where("since<?", 2020)),
+			wantQuery: "SELECT name,email FROM customers WHERE (country=$1) INTERSECT SELECT name,email FROM suppliers WHERE (active=$2) EXCEPT SELECT name,email FROM blocked WHERE (since<$3)",
+			wantArgs:  []interface{}{"NL", true, 2020},
+		},
+		{
+			name:      "paginated member is parenthesized",
+			query:     customers.Union(suppliers.Order("name", OrderDesc).Limit(3)),
+			wantQuery: "SELECT name,email FROM customers WHERE (country=$1) UNION (SELECT name,email FROM suppliers WHERE (active=$2) ORDER BY name DESC LIMIT 3)",
+			wantArgs:  []interface{}{"NL", true},
+		},
+		{
+			name:      "sqlite paginated member is a derived table",
+			query:     New(DialectSqlite3).Select("customers").Columns("name").Union(New(DialectSqlite3).Select("suppliers").Columns("name").Where("active=?", true).Order("name", OrderDesc).Limit(3)),
+			wantQuery: "SELECT name FROM customers UNION SELECT * FROM (SELECT name FROM suppliers WHERE (active=?) ORDER BY name DESC LIMIT 3)",
+			wantArgs:  []interface{}{true},
+		},
+		{
+			name:      "sqlite compound member is a derived table",
+			query:     New(DialectSqlite3).Select("a").Columns("id").Except(New(DialectSqlite3).Select("b").Columns("id").Union(New(DialectSqlite3).Select("c").Columns("id"))),
+			wantQuery: "SELECT id FROM a EXCEPT SELECT * FROM (SELECT id FROM b UNION SELECT id FROM c)",
+		},
+		{
+			name:      "with",
+			query:     qb.Select("a").With("a", "SELECT * FROM t WHERE x=?", 1).Union(qb.Select("b").Where("y=?", 2)),
+			wantQuery: "WITH a AS (SELECT * FROM t WHERE x=$1) SELECT * FROM a UNION SELECT * FROM b WHERE (y=$2)",
+			wantArgs:  []interface{}{1, 2},
+		},
+		{
+			name:      "oracle minus",
+			query:     New(DialectOCI8).Select("a").Columns("id").Except(New(DialectOCI8).Select("b").Columns("id").Where("x=?", 1)),
+			wantQuery: "SELECT id FROM a MINUS SELECT id FROM b WHERE (x=:arg1)",
+			wantArgs:  []interface{}{1},
+		},
+		{
+			name:      "nil query",
+			query:     customers.Union(nil),
+			wantQuery: "SELECT name,email FROM customers WHERE (country=$1)",
+			wantArgs:  []interface{}{"NL"},
+		},
+		{
+			name:    "error",
+			query:   customers.Union(qb.Select("")),
+			wantErr: errors.New(ErrTableIsEmpty),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, args, err := tt.query.Build()
+			require.Equal(t, tt.wantErr, err)
+			require.Equal(t, tt.wantQuery, query)
+			require.Equal(t, tt.wantArgs, args)
+		})
+	}
+}
+
+func TestSelectQuery_ColumnsOf(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     *SelectQuery
+		wantQuery string
+		wantArgs  []interface{}
+		wantErr   error
+	}{
+		{
+			name:      "struct value",
+			query:     Select("users").Columns(scanUser{}),
+			wantQuery: "SELECT id,name,email,city,Country FROM users",
+		},
+		{
+			name:      "pointer to struct",
+			query:     Select("users").Columns(&scanAddress{}),
+			wantQuery: "SELECT city,Country FROM users",
+		},
+		{
+			name:      "nil pointer to struct",
+			query:     Select("users").Columns((*scanAddress)(nil)),
+			wantQuery: "SELECT city,Country FROM users",
+		},
+		{
+			name:      "pointer to struct type",
+			query:     Select("users").Columns(reflect.TypeOf(&scanAddress{})),
+			wantQuery: "SELECT city,Country FROM users",
+		},
+		{
+			name:    "not a struct",
+			query:   Select("users").Columns(1),
+			wantErr: errors.New(ErrUnsupportedQueryType),
+		},
+		{
+			name:      "struct type",
+			query:     Select("users").Columns(reflect.TypeOf(scanAddress{})),
+			wantQuery: "SELECT city,Country FROM users",
+		},
+		{
+			name:      "prefix",
+			query:     New(DialectPostgres).Select("users u").Columns(ColumnsOf(scanUser{}).Prefix("u")).Columns("o.total").Joins("orders o", "o.user_id=u.id", JoinInner).Where("u.id=?", 1),
+			wantQuery: "SELECT u.id,u.name,u.email,u.city,u.Country,o.total FROM users u JOIN orders o ON o.user_id=u.id WHERE (u.id=$1)",
+			wantArgs:  []interface{}{1},
+		},
+		{
+			name:      "quoted",
+			query:     New(DialectMySQL).Quote(QuoteAll).Select("users u").Columns(ColumnsOf(&scanAddress{}).Prefix("u")),
+			wantQuery: "SELECT `u`.`city`,`u`.`Country` FROM `users` `u`",
+		},
+		{
+			name:    "args",
+			query:   Select("users").Columns(scanAddress{}, 1),
+			wantErr: errors.New(ErrWrongNumberOfArgs),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, args, err := tt.query.Build()
+			require.Equal(t, tt.wantErr, err)
+			require.Equal(t, tt.wantQuery, query)
+			require.Equal(t, tt.wantArgs, args)
+		})
+	}
+
+	require.Panics(t, func() { ColumnsOf(1) })
+	require.Panics(t, func() { ColumnsOf(nil) })
+}
+
+func TestSelectQuery_BuildBackslashEscapes(t *testing.T) {
+	query, args, err := New(DialectMySQL).Select("t").Where(`a='x\'?' AND b=? AND c=?`, 1, 2).Build()
+	require.NoError(t, err)
+	require.Equal(t, `SELECT * FROM t WHERE (a='x\'?' AND b=? AND c=?)`, query)
+	require.Equal(t, []interface{}{1, 2}, args)
+
+	_, _, err = New(DialectPostgres).Select("t").Where(`a='x\'?' AND b=? AND c=?`, 1, 2).Build()
+	require.Equal(t, errors.New(ErrWrongNumberOfArgs), err)
+}
+
+func TestSelectQuery_BuildBackslashEscapesSubquery(t *testing.T) {
+	sub := New(DialectMySQL).Select("u").Columns("id").Where("active=?", true)
+	query, args, err := New(DialectMySQL).Select("t").Where(`a='it\'s' AND b IN (?)`, sub).Build()
+	require.NoError(t, err)
+	require.Equal(t, `SELECT * FROM t WHERE (a='it\'s' AND b IN (SELECT id FROM u WHERE (active=?)))`, query)
+	require.Equal(t, []interface{}{true}, args)
+
+	query, args, err = New(DialectMySQL).Select("t").Where(Or(Expr(`a='x\'?'`), Expr("b IN (?)", sub))).Build()
+	require.NoError(t, err)
+	require.Equal(t, `SELECT * FROM t WHERE (((a='x\'?') OR (b IN (SELECT id FROM u WHERE (active=?)))))`, query)
+	require.Equal(t, []interface{}{true}, args)
+}
@@ -2,6 +2,7 @@ package querybuilder
 
 import (
 	"errors"
+	"log"
 	"reflect"
 	"sort"
 	"strings"
@@ -14,6 +15,77 @@ type KeyValue struct {
 
 type IndexedColumnValues map[int]KeyValue
 
+// StructColumns is the list of columns of a struct created by ColumnsOf, to pass to SelectQuery.Columns
+type StructColumns struct {
+	columns []string
+	prefix  string
+	quoter  identifierQuoter
+}
+
+// ColumnsOf returns the columns of a struct value, a pointer to a struct or a reflect.Type of a struct.
+// Fields are mapped to columns like in StructValues: the db tag or the field name, fields tagged with - are skipped
+// and the fields of nested structs are flattened.
+func ColumnsOf(structure interface{}) StructColumns {
+	t, ok := structure.(reflect.Type)
+	if !ok {
+		t = reflect.TypeOf(structure)
+	}
+	if t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		log.Panic(errors.New("unexpected type"))
+	}
+	return StructColumns{columns: structColumns(t)}
+}
+
+// Prefix qualifies the columns with a table name or alias, like u.id,u.name
+func (c StructColumns) Prefix(alias string) StructColumns {
+	c.prefix = alias
+	return c
+}
+
+// names returns the column names, qualified by the prefix if any
+func (c StructColumns) names() []string {
+	names := make([]string, len(c.columns))
+	for i, column := range c.columns {
+		if c.prefix != "" {
+			column = c.prefix + "." + column
+		}
+		names[i] = column
+	}
+	return names
+}
+
+func (c StructColumns) ToSql() (string, []interface{}, error) {
+	names, err := c.quoter.columns(c.names())
+	if err != nil {
+		return "", nil, err
+	}
+	return strings.Join(names, ","), nil, nil
+}
+
+// structColumnsOf returns the StructColumns of a query passed to Columns if it is a struct or a reflect.Type of a struct
+func structColumnsOf(query interface{}) (StructColumns, bool) {
+	switch q := query.(type) {
+	case StructColumns:
+		return q, true
+	case Sqlizer, string, nil:
+		return StructColumns{}, false
+	case reflect.Type:
+		return ColumnsOf(q), true
+	}
+	// the type is used rather than the value, so a nil pointer to a struct is accepted
+	t := reflect.TypeOf(query)
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return StructColumns{}, false
+	}
+	return ColumnsOf(t), true
+}
+
 func mapToIndexColumnValue(columnValues map[string]interface{}) IndexedColumnValues {
 	indexedColumnValues := make(IndexedColumnValues, len(columnValues))
 	columns := make([]string, len(columnValues))
@@ -34,8 +106,44 @@ func mapToIndexColumnValue(columnValues map[string]interface{}) IndexedColumnVal
 	return indexedColumnValues
 }
 
-func structToMap(s interface{}) (IndexedColumnValues, error) {
-	columnValues := make(IndexedColumnValues)
+// structUsage is the query a struct is read for by structToMap, which decides the db tag options applied
+type structUsage int
+
+const (
+	structInsert structUsage = iota
+	structUpdate
+)
+
+// tagOptions are the options which follow the column of a db tag, like db:"id,pk,default"
+type tagOptions map[string]bool
+
+// parseTag returns the column and the options of a db tag
+func parseTag(tag string) (string, tagOptions) {
+	parts := strings.Split(tag, ",")
+	options := make(tagOptions, len(parts)-1)
+	for _, option := range parts[1:] {
+		options[strings.TrimSpace(option)] = true
+	}
+	return parts[0], options
+}
+
+// skip tells whether a field with the options is left out of the query, value is the field value
+func (o tagOptions) skip(usage structUsage, value reflect.Value) bool {
+	if o["readonly"] || (o["omitempty"] && value.IsZero()) {
+		return true
+	}
+	if usage == structInsert {
+		return o["-insert"] || (o["default"] && value.IsZero())
+	}
+	return o["-update"]
+}
+
+// structToMap extracts the column/values of a struct for usage, with the db tag options:
+//   - omitempty skips zero values
+//   - readonly skips the field, -insert and -update skip it on insert or update
+//   - default skips zero values on insert, so the default of the database applies
+//   - pk marks the primary key, returned as the pk condition. On update, it is not set. A nil pk is returned as nil.
+func structToMap(s interface{}, usage structUsage) (IndexedColumnValues, Eq, error) {
 	v := reflect.ValueOf(s)
 	// if its a pointer, resolve its value
 	if v.Kind() == reflect.Ptr {
@@ -43,37 +151,68 @@ func structToMap(s interface{}) (IndexedColumnValues, error) {
 	}
 
 	if v.Kind() != reflect.Struct {
-		return nil, errors.New("unexpected type")
+		return nil, nil, errors.New("unexpected type")
 	}
+	return structValueToMap(v, usage)
+}
+
+// structValueToMap extracts the column/values of the struct v, see structToMap.
+// Unexported fields are skipped, except embedded structs whose exported fields are flattened, like in walkStructFields.
+func structValueToMap(v reflect.Value, usage structUsage) (IndexedColumnValues, Eq, error) {
+	columnValues := make(IndexedColumnValues)
+	pk := make(Eq)
 	e := v.Type()
 	columnIndex := 0
 	for i := 0; i < e.NumField(); i++ {
-		name := e.Field(i).Name
-		tag := strings.Split(e.Field(i).Tag.Get("db"), ",")[0] // use split to ignore tag "options"
+		field := e.Field(i)
+		if field.PkgPath != "" && !field.Anonymous {
+			continue
+		}
+		name := field.Name
+		tag, options := parseTag(field.Tag.Get("db"))
 
 		// ignore columns with -
 		if tag == "-" {
 			continue
 		}
-		value := v.FieldByIndex(e.Field(i).Index)
+		value := v.Field(i)
 		column := tag
 		if tag == "" {
 			column = name
 		}
-		if e.Field(i).Type.Kind() == reflect.Struct {
-			st := reflect.TypeOf(value.Interface())
-			if _, ok := st.MethodByName("String"); !ok {
-				nestedColumnValues, err := structToMap(value.Interface())
+		if field.Type.Kind() == reflect.Struct {
+			if _, ok := field.Type.MethodByName("String"); !ok {
+				nestedColumnValues, nestedPk, err := structValueToMap(value, usage)
 				if err != nil {
-					return nil, err
+					return nil, nil, err
 				}
 				for index := 0; index < len(nestedColumnValues); index++ {
 					columnValues[columnIndex] = KeyValue{Key: nestedColumnValues[index].Key, Value: nestedColumnValues[index].Value}
 					columnIndex++
 				}
+				for pkColumn, pkValue := range nestedPk {
+					pk[pkColumn] = pkValue
+				}
+				continue
+			}
+		}
+		if field.PkgPath != "" {
+			continue
+		}
+
+		if options["pk"] {
+			if value.Kind() == reflect.Ptr && value.IsNil() {
+				pk[column] = nil
+			} else {
+				pk[column] = reflect.Indirect(value).Interface()
+			}
+			if usage == structUpdate {
 				continue
 			}
 		}
+		if options.skip(usage, value) {
+			continue
+		}
 
 		// ignore nil pointer values
 		if value.IsZero() && value.Kind() == reflect.Ptr {
@@ -91,5 +230,5 @@ func structToMap(s interface{}) (IndexedColumnValues, error) {
 		columnValues[columnIndex] = KeyValue{Key: column, Value: value.Interface()}
 		columnIndex++
 	}
-	return columnValues, nil
+	return columnValues, pk, nil
 }
@@ -1,23 +1,35 @@
 package querybuilder
 
 import (
+	"context"
+	"database/sql"
 	"errors"
-	"fmt"
 	"log"
+	"strconv"
 	"strings"
 )
 
 type UpdateQuery struct {
+	dialect             Dialect
+	ctes                []withClause
 	table               string
 	indexedColumnValues IndexedColumnValues
+	joins               []joinClause
 	conditions          []whereClause
+	returning           []string
+	quoteMode           QuoteMode
+	runner              Runner
+	orderBy             []orderByClause
+	limit               interface{}
+	// pk is the primary key set by StructValues, rendered as a condition
+	pk Eq
 }
 
-func (s *UpdateQuery) Where(query string, args ...interface{}) *UpdateQuery {
+// Where adds a condition, query is a raw query string with its args or a Sqlizer like Eq or Gt
+func (s *UpdateQuery) Where(query interface{}, args ...interface{}) *UpdateQuery {
 	args, _ = unifyArgs(args...)
 	condition := whereClause{
-		query: query,
-		args:  args,
+		condition: toSqlizer(query, args),
 	}
 	newQuery := *s
 
@@ -25,8 +37,58 @@ func (s *UpdateQuery) Where(query string, args ...interface{}) *UpdateQuery {
 	return &newQuery
 }
 
+// OrWhere adds a condition which is joined to the previous conditions with OR
+func (s *UpdateQuery) OrWhere(query interface{}, args ...interface{}) *UpdateQuery {
+	args, _ = unifyArgs(args...)
+	condition := whereClause{
+		condition: toSqlizer(query, args),
+		or:        true,
+	}
+	newQuery := *s
+
+	newQuery.conditions = append(newQuery.conditions, condition)
+	return &newQuery
+}
+
+// WhereGroup adds a parenthesized group of conditions joined with AND, the group is filled by fn
+func (s *UpdateQuery) WhereGroup(fn func(g *ConditionGroup)) *UpdateQuery {
+	condition := whereClause{
+		condition: newConditionGroup(fn),
+	}
+	newQuery := *s
+
+	newQuery.conditions = append(newQuery.conditions, condition)
+	return &newQuery
+}
+
+// OrWhereGroup adds a parenthesized group of conditions joined with OR, the group is filled by fn
+func (s *UpdateQuery) OrWhereGroup(fn func(g *ConditionGroup)) *UpdateQuery {
+	condition := whereClause{
+		condition: newConditionGroup(fn),
+		or:        true,
+	}
+	newQuery := *s
+
+	newQuery.conditions = append(newQuery.conditions, condition)
+	return &newQuery
+}
+
+// Joins adds a join with another table, tableName is a table name or a Sqlizer like a subquery created by As.
+// It is rendered as UPDATE ... FROM, UPDATE ... JOIN or UPDATE ... FROM ... JOIN depending on the dialect, only inner joins can be moved to the WHERE part.
+func (s *UpdateQuery) Joins(tableName interface{}, on interface{}, joinType JoinType, args ...interface{}) *UpdateQuery {
+	args, _ = unifyArgs(args...)
+	join := joinClause{
+		table:    tableSqlizer(tableName),
+		on:       toSqlizer(on, args),
+		joinType: joinType,
+	}
+	newQuery := *s
+	newQuery.joins = append(newQuery.joins, join)
+	return &newQuery
+}
+
 // MapValues gets columns and values,
-// Enter Column/Values as a key/value map
+// Enter Column/Values as a key/value map, the columns are raw SQL unless the Builder quotes them, see Builder.Quote
 func (s *UpdateQuery) MapValues(columnValues map[string]interface{}) *UpdateQuery {
 	newQuery := *s
 	newQuery.indexedColumnValues = mapToIndexColumnValue(columnValues)
@@ -34,52 +96,234 @@ func (s *UpdateQuery) MapValues(columnValues map[string]interface{}) *UpdateQuer
 }
 
 // StructValues gets and struct and extract column/values,
+// fields tagged with the readonly or -update options, and zero fields tagged with omitempty are skipped.
+// Fields tagged with pk are not set, they are the condition of the query instead, which replaces the one of a previous call.
 func (s *UpdateQuery) StructValues(structure interface{}) *UpdateQuery {
 	newQuery := *s
-	m, err := structToMap(structure)
+	m, pk, err := structToMap(structure, structUpdate)
 	if err != nil {
 		log.Panic(err)
 	}
 	newQuery.indexedColumnValues = m
+	newQuery.pk = pk
+	return &newQuery
+}
+
+// Returning sets the columns returned by the query, rendered as RETURNING or, on SQL Server, as OUTPUT INSERTED.column
+func (s *UpdateQuery) Returning(columns ...string) *UpdateQuery {
+	newQuery := *s
+	newQuery.returning = columns
+	return &newQuery
+}
+
+// Order adds a column to the ORDER BY of the query, only supported by MySQL and SQLite
+func (s *UpdateQuery) Order(column string, direction OrderDirection) *UpdateQuery {
+	clause := orderByClause{
+		field:     column,
+		direction: direction,
+	}
+	newQuery := *s
+	newQuery.orderBy = append(newQuery.orderBy, clause)
 	return &newQuery
 }
 
+// Limit sets the maximum number of rows changed by the query, only supported by MySQL and SQLite
+func (s *UpdateQuery) Limit(limit interface{}) *UpdateQuery {
+	newQuery := *s
+	newQuery.limit = toInt64(limit)
+	return &newQuery
+}
+
+// With adds a common table expression, query is a raw query string with its args or a Sqlizer like a SelectQuery
+func (s *UpdateQuery) With(name string, query interface{}, args ...interface{}) *UpdateQuery {
+	args, _ = unifyArgs(args...)
+	cte := withClause{
+		name:  name,
+		query: toSqlizer(query, args),
+	}
+	newQuery := *s
+	newQuery.ctes = append(newQuery.ctes, cte)
+	return &newQuery
+}
+
+// WithRecursive adds a recursive common table expression with its columns
+func (s *UpdateQuery) WithRecursive(name string, columns string, query interface{}, args ...interface{}) *UpdateQuery {
+	args, _ = unifyArgs(args...)
+	cte := withClause{
+		name:      name,
+		columns:   columns,
+		query:     toSqlizer(query, args),
+		recursive: true,
+	}
+	newQuery := *s
+	newQuery.ctes = append(newQuery.ctes, cte)
+	return &newQuery
+}
+
+// quoter returns the identifierQuoter of the query's dialect and quote mode
+func (s *UpdateQuery) quoter() identifierQuoter {
+	return identifierQuoter{dialect: s.dialect, mode: s.quoteMode}
+}
+
+// RunWith sets the runner which executes the query, like a *sql.DB, *sql.Tx or *sql.Conn
+func (s *UpdateQuery) RunWith(runner Runner) *UpdateQuery {
+	newQuery := *s
+	newQuery.runner = runner
+	return &newQuery
+}
+
+// ExecContext builds the query and executes it with the runner set by RunWith
+func (s *UpdateQuery) ExecContext(ctx context.Context) (sql.Result, error) {
+	query, args, err := s.Build()
+	return execContext(ctx, s.runner, query, args, err)
+}
+
+// QueryContext builds the query and runs it with the runner set by RunWith
+func (s *UpdateQuery) QueryContext(ctx context.Context) (*sql.Rows, error) {
+	query, args, err := s.Build()
+	return queryContext(ctx, s.runner, query, args, err)
+}
+
+// QueryRowContext builds the query and runs it with the runner set by RunWith, the error of building the query is returned by Row.Scan
+func (s *UpdateQuery) QueryRowContext(ctx context.Context) *Row {
+	query, args, err := s.Build()
+	return queryRowContext(ctx, s.runner, query, args, err)
+}
+
+// Build builds the query and its arguments, placeholders are rendered for the query's dialect
 func (s *UpdateQuery) Build() (string, []interface{}, error) {
+	query, args, err := s.build()
+	if err != nil {
+		return "", nil, err
+	}
+	return rebind(orDefault(s.dialect), query), args, nil
+}
+
+// build builds the query with QUESTION placeholders
+func (s *UpdateQuery) build() (string, []interface{}, error) {
 	if s.table == "" {
 		return "", nil, errors.New(ErrTableIsEmpty)
 	}
 	if len(s.indexedColumnValues) == 0 {
 		return "", nil, errors.New(ErrColumnValueMapIsEmpty)
 	}
+	q := s.quoter()
+	table, err := q.table(s.table)
+	if err != nil {
+		return "", nil, err
+	}
+	returningColumns, err := q.columns(s.returning)
+	if err != nil {
+		return "", nil, err
+	}
+	returning, output, err := buildReturning(orDefault(s.dialect), returningColumns, "INSERTED")
+	if err != nil {
+		return "", nil, err
+	}
+	columnValues, err := q.columnValues(s.indexedColumnValues)
+	if err != nil {
+		return "", nil, err
+	}
 	var query string
-	args := make([]interface{}, len(s.indexedColumnValues))
-
-	// make column slice
-	columns := make([]string, len(s.indexedColumnValues))
+	var args []interface{}
+	var setArgs []interface{}
 	var setQuery []string
 
-	for i := 0; i < len(s.indexedColumnValues); i++ {
-		indexedColumnValue := s.indexedColumnValues[i]
-		columns[i] = indexedColumnValue.Key
-		args[i] = indexedColumnValue.Value
-		setQuery = append(setQuery, columns[i]+"=?")
+	for i := 0; i < len(columnValues); i++ {
+		indexedColumnValue := columnValues[i]
+		assignment, assignmentArgs, err := assignmentSql(s.dialect, indexedColumnValue.Key, indexedColumnValue.Key, indexedColumnValue.Value)
+		if err != nil {
+			return "", nil, err
+		}
+		setQuery = append(setQuery, assignment)
+		setArgs = append(setArgs, assignmentArgs...)
 	}
+	set := strings.Join(setQuery, ",")
 
-	query = "UPDATE " + s.table + " SET " + strings.Join(setQuery, ",")
+	//
+	// add table name and joins
+	conditions, err := pkConditions(q, s.pk, s.conditions)
+	if err != nil {
+		return "", nil, err
+	}
+	d := orDefault(s.dialect)
+	switch {
+	case len(s.joins) == 0:
+		query = "UPDATE " + table + " SET " + set + output
+		args = setArgs
+	case d.Supports(FeatureUpdateFrom):
+		tables, tableArgs, joinConditions, err := buildJoinTables(s.dialect, s.joins, conditions)
+		if err != nil {
+			return "", nil, err
+		}
+		query = "UPDATE " + table + " SET " + set + output + " FROM " + tables
+		args = append(setArgs, tableArgs...)
+		conditions = joinConditions
+	case d.Supports(FeatureUpdateJoin):
+		joins, joinArgs, err := buildJoins(s.dialect, s.joins)
+		if err != nil {
+			return "", nil, err
+		}
+		query = "UPDATE " + table + joins + " SET " + set + output
+		args = append(joinArgs, setArgs...)
+	case d.Supports(FeatureUpdateFromJoin):
+		joins, joinArgs, err := buildJoins(s.dialect, s.joins)
+		if err != nil {
+			return "", nil, err
+		}
+		query = "UPDATE " + tableAlias(table) + " SET " + set + output + " FROM " + table + joins
+		args = append(setArgs, joinArgs...)
+	default:
+		return "", nil, errors.New(ErrJoinNotSupported)
+	}
 
 	//
 	// check for where part
-	if len(s.conditions) > 0 {
-		var conditionsSlice []string
-		for _, condition := range s.conditions {
-			conditionsSlice = append(conditionsSlice, fmt.Sprintf("(%s)", condition.query))
-			args = append(args, condition.args...)
+	if len(conditions) > 0 {
+		conditions, conditionArgs, err := buildConditions(s.dialect, conditions)
+		if err != nil {
+			return "", nil, err
+		}
+		if conditions != "" {
+			query = query + " WHERE " + conditions
+			args = append(args, conditionArgs...)
+		}
+	}
+
+	//
+	// add returning columns
+	query = query + returning
+
+	//
+	// add order by and limit
+	if len(s.orderBy) > 0 || s.limit != nil {
+		if !d.Supports(FeatureUpdateLimit) || len(s.joins) > 0 {
+			return "", nil, errors.New(ErrOrderLimitNotSupported)
+		}
+		if len(s.orderBy) > 0 {
+			query = query + " ORDER BY " + buildOrderBy(s.orderBy)
+		}
+		if s.limit != nil {
+			query = query + " LIMIT " + strconv.FormatInt(s.limit.(int64), 10)
+		}
+	}
+
+	//
+	// add common table expressions
+	if len(s.ctes) > 0 {
+		if !d.Supports(FeatureWithUpdateDelete) {
+			return "", nil, errors.New(ErrWithNotSupported)
+		}
+		with, withArgs, err := buildWith(orDefault(s.dialect), s.ctes)
+		if err != nil {
+			return "", nil, err
 		}
-		query = query + " WHERE " + strings.Join(conditionsSlice, " AND ")
+		query = with + query
+		args = append(withArgs, args...)
 	}
 
 	// compare the number of args and ? in tableName
-	if len(args) != strings.Count(query, "?") {
+	if len(args) != countPlaceholders(s.dialect, query) {
 		return "", nil, errors.New(ErrWrongNumberOfArgs)
 	}
 
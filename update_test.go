@@ -175,3 +175,225 @@ func TestUpdateQuery_Rebind(t *testing.T) {
 		})
 	}
 }
+
+func TestUpdateQuery_BuildDialect(t *testing.T) {
+	query, args, err := New(DialectPostgres).Update("table1").
+		MapValues(map[string]interface{}{"field1": 10, "field2": "test"}).
+		Where("id=?", 5000).
+		Build()
+	require.NoError(t, err)
+	require.Equal(t, "UPDATE table1 SET field1=$1,field2=$2 WHERE (id=$3)", query)
+	require.Equal(t, []interface{}{10, "test", 5000}, args)
+
+	query, _, err = New(DialectORA).Update("table1").
+		MapValues(map[string]interface{}{"field1": 10, "field2": "test"}).
+		Where("id=?", 5000).
+		Build()
+	require.NoError(t, err)
+	require.Equal(t, "UPDATE table1 SET field1=:arg1,field2=:arg2 WHERE (id=:arg3)", query)
+}
+
+func TestUpdateQuery_BuildReturning(t *testing.T) {
+	columnValues := map[string]interface{}{"field1": "value1"}
+
+	query, args, err := New(DialectPostgres).Update("table1").MapValues(columnValues).Where("id=?", 1).Returning("id", "field1").Build()
+	require.NoError(t, err)
+	require.Equal(t, "UPDATE table1 SET field1=$1 WHERE (id=$2) RETURNING id,field1", query)
+	require.Equal(t, []interface{}{"value1", 1}, args)
+
+	query, _, err = New(DialectSqlServer).Update("table1").MapValues(columnValues).Where("id=?", 1).Returning("id").Build()
+	require.NoError(t, err)
+	require.Equal(t, "UPDATE table1 SET field1=@p1 OUTPUT INSERTED.id WHERE (id=@p2)", query)
+
+	_, _, err = New(DialectOCI8).Update("table1").MapValues(columnValues).Returning("id").Build()
+	require.EqualError(t, err, ErrReturningNotSupported)
+}
+
+func TestUpdateQuery_BuildJoins(t *testing.T) {
+	columnValues := map[string]interface{}{"status": "vip"}
+
+	tests := []struct {
+		name      string
+		query     func(b *Builder) *UpdateQuery
+		dialect   Dialect
+		wantQuery string
+		wantArgs  []interface{}
+		wantErr   bool
+	}{
+		{
+			name:    "postgres from",
+			dialect: DialectPostgres,
+			query: func(b *Builder) *UpdateQuery {
+				return b.Update("users u").MapValues(columnValues).Joins("orders o", "o.user_id=u.id", JoinInner).Where("o.total>?", 100)
+			},
+			wantQuery: "UPDATE users u SET status=$1 FROM orders o WHERE (o.user_id=u.id) AND (o.total>$2)",
+			wantArgs:  []interface{}{"vip", 100},
+		},
+		{
+			name:    "postgres from with or conditions",
+			dialect: DialectPostgres,
+			query: func(b *Builder) *UpdateQuery {
+				return b.Update("users u").MapValues(columnValues).Joins("orders o", "o.user_id=u.id", JoinInner).
+					Where("o.total>?", 100).OrWhere("u.age>?", 50)
+			},
+			wantQuery: "UPDATE users u SET status=$1 FROM orders o WHERE (o.user_id=u.id) AND ((o.total>$2) OR (u.age>$3))",
+			wantArgs:  []interface{}{"vip", 100, 50},
+		},
+		{
+			name:    "mysql join",
+			dialect: DialectMySQL,
+			query: func(b *Builder) *UpdateQuery {
+				return b.Update("users u").MapValues(columnValues).Joins("orders o", "o.user_id=u.id AND o.total>?", JoinLeft, 100).Where("u.age>?", 50)
+			},
+			wantQuery: "UPDATE users u LEFT JOIN orders o ON o.user_id=u.id AND o.total>? SET status=? WHERE (u.age>?)",
+			wantArgs:  []interface{}{100, "vip", 50},
+		},
+		{
+			name:    "sql server from join",
+			dialect: DialectSqlServer,
+			query: func(b *Builder) *UpdateQuery {
+				return b.Update("users u").MapValues(columnValues).Joins("orders o", "o.user_id=u.id", JoinInner).Where("o.total>?", 100)
+			},
+			wantQuery: "UPDATE u SET status=@p1 FROM users u JOIN orders o ON o.user_id=u.id WHERE (o.total>@p2)",
+			wantArgs:  []interface{}{"vip", 100},
+		},
+		{
+			name:    "postgres left join",
+			dialect: DialectPostgres,
+			query: func(b *Builder) *UpdateQuery {
+				return b.Update("users u").MapValues(columnValues).Joins("orders o", "o.user_id=u.id", JoinLeft)
+			},
+			wantErr: true,
+		},
+		{
+			name:    "oracle",
+			dialect: DialectOCI8,
+			query: func(b *Builder) *UpdateQuery {
+				return b.Update("users u").MapValues(columnValues).Joins("orders o", "o.user_id=u.id", JoinInner)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotQuery, gotArgs, err := tt.query(New(tt.dialect)).Build()
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.wantQuery, gotQuery)
+			require.Equal(t, tt.wantArgs, gotArgs)
+		})
+	}
+}
+
+func TestUpdateQuery_BuildExprValues(t *testing.T) {
+	query, args, err := Update("table1").MapValues(map[string]interface{}{
+		"counter":    Increment(1),
+		"stock":      Decrement(2),
+		"data":       Expr("data || ?", "suffix"),
+		"updated_at": Expr("NOW()"),
+		"name":       "value1",
+	}).Where("id=?", 10).Build()
+	require.NoError(t, err)
+	require.Equal(t, "UPDATE table1 SET counter=counter+?,data=data || ?,name=?,stock=stock-?,updated_at=NOW() WHERE (id=?)", query)
+	require.Equal(t, []interface{}{1, "suffix", "value1", 2, 10}, args)
+
+	type row struct {
+		Name      string  `db:"name"`
+		UpdatedAt Sqlizer `db:"updated_at"`
+	}
+	query, args, err = New(DialectPostgres).Update("table1").StructValues(row{Name: "value1", UpdatedAt: Expr("NOW()")}).Build()
+	require.NoError(t, err)
+	require.Equal(t, "UPDATE table1 SET name=$1,updated_at=NOW()", query)
+	require.Equal(t, []interface{}{"value1"}, args)
+
+	query, args, err = Update("table1").MapValues(map[string]interface{}{
+		"total": Select("orders").Columns("SUM(amount)").Where("user_id=?", 1),
+	}).Build()
+	require.NoError(t, err)
+	require.Equal(t, "UPDATE table1 SET total=(SELECT SUM(amount) FROM orders WHERE (user_id=?))", query)
+	require.Equal(t, []interface{}{1}, args)
+}
+
+func TestUpdateQuery_BuildOrderLimit(t *testing.T) {
+	columnValues := map[string]interface{}{"status": "archived"}
+
+	query, args, err := New(DialectMySQL).Update("table1").MapValues(columnValues).Where("created_at<?", "2020-01-01").
+		Order("created_at", OrderAsc).Limit(1000).Build()
+	require.NoError(t, err)
+	require.Equal(t, "UPDATE table1 SET status=? WHERE (created_at<?) ORDER BY created_at ASC LIMIT 1000", query)
+	require.Equal(t, []interface{}{"archived", "2020-01-01"}, args)
+
+	query, _, err = New(DialectSqlite3).Update("table1").MapValues(columnValues).Limit("10").Returning("id").Build()
+	require.NoError(t, err)
+	require.Equal(t, "UPDATE table1 SET status=? RETURNING id LIMIT 10", query)
+
+	_, _, err = New(DialectPostgres).Update("table1").MapValues(columnValues).Limit(10).Build()
+	require.EqualError(t, err, ErrOrderLimitNotSupported)
+
+	_, _, err = New(DialectMySQL).Update("table1").MapValues(columnValues).Joins("table2", "table2.id=table1.id", JoinInner).Limit(10).Build()
+	require.EqualError(t, err, ErrOrderLimitNotSupported)
+}
+
+func TestUpdateQuery_BuildTagOptions(t *testing.T) {
+	type membership struct {
+		UserID  int64  `db:"user_id,pk"`
+		GroupID *int64 `db:"order,pk"`
+		Role    string `db:"role"`
+	}
+	groupID := int64(2)
+
+	tests := []struct {
+		name      string
+		query     *UpdateQuery
+		wantQuery string
+		wantArgs  []interface{}
+		wantErr   error
+	}{
+		{
+			name:      "pk condition",
+			query:     Update("users").StructValues(taggedUser{ID: 1, Name: "a", Audit: taggedAudit{CreatedAt: time.Now(), CreatedBy: "b", UpdatedBy: "c"}}),
+			wantQuery: "UPDATE users SET name=?,status=?,updated_by=? WHERE (id=?)",
+			wantArgs:  []interface{}{"a", "", "c", int64(1)},
+		},
+		{
+			name:      "pk condition and where",
+			query:     New(DialectPostgres).Update("users").StructValues(&taggedUser{ID: 1, Level: 3}).Where("status=?", "active"),
+			wantQuery: "UPDATE users SET name=$1,status=$2,level=$3,updated_by=$4 WHERE (id=$5) AND (status=$6)",
+			wantArgs:  []interface{}{"", "", 3, "", int64(1), "active"},
+		},
+		{
+			name:      "pk condition and or where",
+			query:     Update("users").StructValues(taggedUser{ID: 1}).Where("status=?", "active").OrWhere("level=?", 1),
+			wantQuery: "UPDATE users SET name=?,status=?,updated_by=? WHERE (id=?) AND ((status=?) OR (level=?))",
+			wantArgs:  []interface{}{"", "", "", int64(1), "active", 1},
+		},
+		{
+			name:      "struct values replaces pk",
+			query:     Update("users").StructValues(taggedUser{ID: 1}).StructValues(taggedUser{ID: 2, Name: "b"}),
+			wantQuery: "UPDATE users SET name=?,status=?,updated_by=? WHERE (id=?)",
+			wantArgs:  []interface{}{"b", "", "", int64(2)},
+		},
+		{
+			name:      "composite pk quoted",
+			query:     New(DialectMySQL).Quote(QuoteAll).Update("memberships").StructValues(membership{UserID: 1, GroupID: &groupID, Role: "admin"}),
+			wantQuery: "UPDATE `memberships` SET `role`=? WHERE (`order`=? AND `user_id`=?)",
+			wantArgs:  []interface{}{"admin", int64(2), int64(1)},
+		},
+		{
+			name:    "nil pk",
+			query:   Update("memberships").StructValues(membership{UserID: 1, Role: "admin"}),
+			wantErr: errors.New(ErrPrimaryKeyIsNull + ": order"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, args, err := tt.query.Build()
+			require.Equal(t, tt.wantErr, err)
+			require.Equal(t, tt.wantQuery, query)
+			require.Equal(t, tt.wantArgs, args)
+		})
+	}
+}
@@ -0,0 +1,197 @@
+package querybuilder
+
+import (
+	"errors"
+	"strings"
+)
+
+type conflictClause struct {
+	columns   []string
+	doNothing bool
+	set       IndexedColumnValues
+}
+
+type excluded struct {
+	column string
+}
+
+// ToSql renders the PostgreSQL form, an upsert renders the reference for its dialect
+func (e excluded) ToSql() (string, []interface{}, error) {
+	return "EXCLUDED." + e.column, nil, nil
+}
+
+// Excluded references the value of column in the row proposed for insertion.
+// Use it as a value of DoUpdateSet or OnDuplicateKeyUpdate, it renders EXCLUDED.column, VALUES(column) or source.column depending on the dialect.
+func Excluded(column string) Sqlizer {
+	return excluded{column: column}
+}
+
+// buildUpsert renders the INSERT of columns and values, or of the select, with the conflict action for the dialect.
+// insert and args are the plain INSERT query used by the dialects with ON CONFLICT and ON DUPLICATE KEY UPDATE,
+// output is the OUTPUT clause of a MERGE.
+func (s *InsertQuery) buildUpsert(d Dialect, insert string, args []interface{}, columns []string, values [][]interface{}, output string) (string, []interface{}, error) {
+	conflict, err := s.quoter().conflict(s.conflict)
+	if err != nil {
+		return "", nil, err
+	}
+	if !conflict.doNothing && len(conflict.set) == 0 {
+		return "", nil, errors.New(ErrConflictActionIsEmpty)
+	}
+
+	switch {
+	case d.Supports(FeatureOnConflict):
+		query := insert + " ON CONFLICT"
+		if len(conflict.columns) > 0 {
+			query = query + " (" + strings.Join(conflict.columns, ",") + ")"
+		}
+		if conflict.doNothing {
+			return query + " DO NOTHING", args, nil
+		}
+		if len(conflict.columns) == 0 {
+			return "", nil, errors.New(ErrConflictTargetIsEmpty)
+		}
+		// the current value is qualified by the table, an unqualified column is ambiguous with EXCLUDED
+		table, err := s.quoter().table(s.table)
+		if err != nil {
+			return "", nil, err
+		}
+		set, setArgs, err := buildConflictSet(d, conflict.set, func(column string) string { return "EXCLUDED." + column }, tableAlias(table)+".")
+		if err != nil {
+			return "", nil, err
+		}
+		return query + " DO UPDATE SET " + set, append(args, setArgs...), nil
+
+	case d.Supports(FeatureOnDuplicateKey):
+		if conflict.doNothing {
+			// there is no DO NOTHING, a no-op assignment keeps the existing row
+			if len(conflict.columns) == 0 && len(columns) == 0 {
+				return "", nil, errors.New(ErrConflictTargetIsEmpty)
+			}
+			column := columns[0]
+			if len(conflict.columns) > 0 {
+				column = conflict.columns[0]
+			}
+			return insert + " ON DUPLICATE KEY UPDATE " + column + "=" + column, args, nil
+		}
+		set, setArgs, err := buildConflictSet(d, conflict.set, func(column string) string { return "VALUES(" + column + ")" }, "")
+		if err != nil {
+			return "", nil, err
+		}
+		return insert + " ON DUPLICATE KEY UPDATE " + set, append(args, setArgs...), nil
+
+	case d.Supports(FeatureMerge):
+		if len(conflict.columns) == 0 {
+			return "", nil, errors.New(ErrConflictTargetIsEmpty)
+		}
+		if len(columns) == 0 {
+			return "", nil, errors.New(ErrColumnsIsEmpty)
+		}
+		return s.buildMerge(d, conflict, columns, values, output)
+	}
+	return "", nil, errors.New(ErrUpsertNotSupported)
+}
+
+// buildMerge renders an upsert as a MERGE statement with the rows or the select as source
+func (s *InsertQuery) buildMerge(d Dialect, conflict *conflictClause, columns []string, values [][]interface{}, output string) (string, []interface{}, error) {
+	table, err := s.quoter().table(s.table)
+	if err != nil {
+		return "", nil, err
+	}
+	var args []interface{}
+	var source string
+	rows := make([]string, len(values))
+	switch {
+	case s.fromSelect != nil:
+		sql, selectArgs, err := s.selectSource(d)
+		if err != nil {
+			return "", nil, err
+		}
+		args = selectArgs
+		if d.Supports(FeatureValuesTable) {
+			source = " AS target USING (" + sql + ") AS source"
+		} else {
+			source = " target USING (" + sql + ") source"
+		}
+	case d.Supports(FeatureValuesTable):
+		for i, row := range values {
+			sqls, rowArgs, err := rowValueSql(d, row)
+			if err != nil {
+				return "", nil, err
+			}
+			rows[i] = "(" + strings.Join(sqls, ",") + ")"
+			args = append(args, rowArgs...)
+		}
+		source = " AS target USING (VALUES" + strings.Join(rows, ",") + ") AS source(" + strings.Join(columns, ",") + ")"
+	default:
+		for i, row := range values {
+			sqls, rowArgs, err := rowValueSql(d, row)
+			if err != nil {
+				return "", nil, err
+			}
+			for j, column := range columns {
+				sqls[j] = sqls[j] + " " + column
+			}
+			rows[i] = "SELECT " + strings.Join(sqls, ",") + " FROM dual"
+			args = append(args, rowArgs...)
+		}
+		source = " target USING (" + strings.Join(rows, " UNION ALL ") + ") source"
+	}
+
+	on := make([]string, len(conflict.columns))
+	for i, column := range conflict.columns {
+		on[i] = "target." + column + "=source." + column
+	}
+	sourceColumns := make([]string, len(columns))
+	for i, column := range columns {
+		sourceColumns[i] = "source." + column
+	}
+
+	query := "MERGE INTO " + table + source + " ON (" + strings.Join(on, " AND ") + ")"
+	if !conflict.doNothing {
+		set, setArgs, err := buildConflictSet(d, conflict.set, func(column string) string { return "source." + column }, "target.")
+		if err != nil {
+			return "", nil, err
+		}
+		query = query + " WHEN MATCHED THEN UPDATE SET " + set
+		args = append(args, setArgs...)
+	}
+	query = query + " WHEN NOT MATCHED THEN INSERT(" + strings.Join(columns, ",") + ") VALUES(" + strings.Join(sourceColumns, ",") + ")" + output
+	if d.Driver() == DriverSqlServer {
+		// SQL Server requires MERGE to be terminated by a semicolon
+		query = query + ";"
+	}
+	return query, args, nil
+}
+
+// conflictSafeSelect returns the select of an INSERT ... SELECT ... ON CONFLICT for SQLite,
+// which parses ON CONFLICT as the join constraint of a select ending with its FROM clause.
+// A select without conditions gets WHERE true and a compound select is selected from as a derived table with WHERE true.
+func conflictSafeSelect(query *SelectQuery) *SelectQuery {
+	if len(query.compounds) > 0 {
+		query = &SelectQuery{dialect: query.dialect, from: derivedTable{query: query, alias: "source"}}
+	} else if len(query.conditions) > 0 || query.keyset != nil {
+		return query
+	}
+	return query.Where("true")
+}
+
+// buildConflictSet renders the assignments of a conflict action, reference renders an Excluded value
+// and target qualifies the current value of a column for Increment and Decrement
+func buildConflictSet(d Dialect, set IndexedColumnValues, reference func(column string) string, target string) (string, []interface{}, error) {
+	assignments := make([]string, len(set))
+	var args []interface{}
+	for i := 0; i < len(set); i++ {
+		column, value := set[i].Key, set[i].Value
+		if e, ok := value.(excluded); ok {
+			assignments[i] = column + "=" + reference(e.column)
+			continue
+		}
+		assignment, assignmentArgs, err := assignmentSql(d, column, target+column, value)
+		if err != nil {
+			return "", nil, err
+		}
+		assignments[i] = assignment
+		args = append(args, assignmentArgs...)
+	}
+	return strings.Join(assignments, ","), args, nil
+}
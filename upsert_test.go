@@ -0,0 +1,125 @@
+package querybuilder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestInsertQuery_BuildUpsert(t *testing.T) {
+	columnValues := map[string]interface{}{"id": 1, "name": "value1"}
+	set := map[string]interface{}{"name": Excluded("name"), "counter": 5}
+
+	tests := []struct {
+		name      string
+		query     *InsertQuery
+		wantQuery string
+		wantArgs  []interface{}
+		wantErr   bool
+	}{
+		{
+			name:      "postgres do update",
+			query:     New(DialectPostgres).Insert("table1").MapValues(columnValues).OnConflict("id").DoUpdateSet(set),
+			wantQuery: "INSERT INTO table1(id,name) VALUES($1,$2) ON CONFLICT (id) DO UPDATE SET counter=$3,name=EXCLUDED.name",
+			wantArgs:  []interface{}{1, "value1", 5},
+		},
+		{
+			name:      "postgres do nothing",
+			query:     New(DialectPostgres).Insert("table1").MapValues(columnValues).OnConflict("id").DoNothing(),
+			wantQuery: "INSERT INTO table1(id,name) VALUES($1,$2) ON CONFLICT (id) DO NOTHING",
+			wantArgs:  []interface{}{1, "value1"},
+		},
+		{
+			name:      "sqlite do nothing without target",
+			query:     New(DialectSqlite3).Insert("table1").MapValues(columnValues).DoNothing(),
+			wantQuery: "INSERT INTO table1(id,name) VALUES(?,?) ON CONFLICT DO NOTHING",
+			wantArgs:  []interface{}{1, "value1"},
+		},
+		{
+			name:      "sqlite select without conditions",
+			query:     New(DialectSqlite3).Insert("table1").Columns("id", "name").FromSelect(New(DialectSqlite3).Select("table2").Columns("id,name")).OnConflict("id").DoNothing(),
+			wantQuery: "INSERT INTO table1(id,name) SELECT id,name FROM table2 WHERE (true) ON CONFLICT (id) DO NOTHING",
+		},
+		{
+			name:      "sqlite select with conditions",
+			query:     New(DialectSqlite3).Insert("table1").Columns("id", "name").FromSelect(New(DialectSqlite3).Select("table2").Columns("id,name").Where("id>?", 1)).OnConflict("id").DoNothing(),
+			wantQuery: "INSERT INTO table1(id,name) SELECT id,name FROM table2 WHERE (id>?) ON CONFLICT (id) DO NOTHING",
+			wantArgs:  []interface{}{1},
+		},
+		{
+			name: "sqlite compound select",
+			query: New(DialectSqlite3).Insert("table1").Columns("id").
+				FromSelect(New(DialectSqlite3).Select("table2").Columns("id").Where("id>?", 1).Union(New(DialectSqlite3).Select("table3").Columns("id"))).
+				OnConflict("id").DoNothing(),
+			wantQuery: "INSERT INTO table1(id) SELECT * FROM (SELECT id FROM table2 WHERE (id>?) UNION SELECT id FROM table3) source WHERE (true) ON CONFLICT (id) DO NOTHING",
+			wantArgs:  []interface{}{1},
+		},
+		{
+			name:      "postgres select without conditions",
+			query:     New(DialectPostgres).Insert("table1").Columns("id", "name").FromSelect(New(DialectPostgres).Select("table2").Columns("id,name")).OnConflict("id").DoNothing(),
+			wantQuery: "INSERT INTO table1(id,name) SELECT id,name FROM table2 ON CONFLICT (id) DO NOTHING",
+		},
+		{
+			name:    "postgres do update without target",
+			query:   New(DialectPostgres).Insert("table1").MapValues(columnValues).DoUpdateSet(set),
+			wantErr: true,
+		},
+		{
+			name:      "mysql on duplicate key update",
+			query:     New(DialectMySQL).Insert("table1").MapValues(columnValues).OnDuplicateKeyUpdate(set),
+			wantQuery: "INSERT INTO table1(id,name) VALUES(?,?) ON DUPLICATE KEY UPDATE counter=?,name=VALUES(name)",
+			wantArgs:  []interface{}{1, "value1", 5},
+		},
+		{
+			name:      "mysql do nothing",
+			query:     New(DialectMySQL).Insert("table1").MapValues(columnValues).OnConflict("id").DoNothing(),
+			wantQuery: "INSERT INTO table1(id,name) VALUES(?,?) ON DUPLICATE KEY UPDATE id=id",
+			wantArgs:  []interface{}{1, "value1"},
+		},
+		{
+			name: "sql server merge",
+			query: New(DialectSqlServer).Insert("table1").
+				Rows([]map[string]interface{}{columnValues, {"id": 2, "name": "value2"}}).
+				OnConflict("id").DoUpdateSet(set),
+			wantQuery: "MERGE INTO table1 AS target USING (VALUES(@p1,@p2),(@p3,@p4)) AS source(id,name) ON (target.id=source.id) " +
+				"WHEN MATCHED THEN UPDATE SET counter=@p5,name=source.name " +
+				"WHEN NOT MATCHED THEN INSERT(id,name) VALUES(source.id,source.name);",
+			wantArgs: []interface{}{1, "value1", 2, "value2", 5},
+		},
+		{
+			name:      "oracle merge do nothing",
+			query:     New(DialectOCI8).Insert("table1").MapValues(columnValues).OnConflict("id").DoNothing(),
+			wantQuery: "MERGE INTO table1 target USING (SELECT :arg1 id,:arg2 name FROM dual) source ON (target.id=source.id) WHEN NOT MATCHED THEN INSERT(id,name) VALUES(source.id,source.name)",
+			wantArgs:  []interface{}{1, "value1"},
+		},
+		{
+			name:    "merge without target",
+			query:   New(DialectSqlServer).Insert("table1").MapValues(columnValues).DoNothing(),
+			wantErr: true,
+		},
+		{
+			name:    "missing action",
+			query:   Insert("table1").MapValues(columnValues).OnConflict("id"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotQuery, gotArgs, err := tt.query.Build()
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.wantQuery, gotQuery)
+			require.Equal(t, tt.wantArgs, gotArgs)
+		})
+	}
+}
+
+func TestExcluded(t *testing.T) {
+	sql, args, err := Excluded("name").ToSql()
+	require.NoError(t, err)
+	require.Equal(t, "EXCLUDED.name", sql)
+	require.Nil(t, args)
+}